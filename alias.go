@@ -0,0 +1,50 @@
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindAsNamed binds the interface type I to the existing binding of the
+// concrete type T with the specified name, such that resolving I resolves T.
+// Both resolve to the same underlying provider, so a Singleton bound to T is
+// shared with I. The binding for T is looked up when I is resolved, so it may
+// be bound before or after calling BindAsNamed.
+//
+// BindAsNamed panics if I is not an interface type or if T does not implement
+// I.
+func BindAsNamed[I, T any](c *Container, name string) {
+	bindAs[I, T](c, name, callerSource(0))
+}
+
+func bindAs[I, T any](c *Container, name string, source string) {
+	iface, impl := typeOf[I](), typeOf[T]()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("ioc.BindAs: %v is not an interface type", iface))
+	}
+	if !impl.Implements(iface) {
+		panic(fmt.Sprintf("ioc.BindAs: %v does not implement %v", impl, iface))
+	}
+
+	target := newTypeName[T](name)
+	fn := ProviderFunc[I](func(c *Container) (value I, err error) {
+		v, err := tryResolve[T](c.Context(), c, target)
+		if err != nil {
+			return value, err
+		}
+		return any(v).(I), nil
+	})
+
+	c.bind(newTypeName[I](name), registration{
+		provider: fn.provide,
+		source:   source,
+		kind:     "alias",
+	}, false)
+}
+
+// BindAs binds the interface type I to the existing anonymous binding of the
+// concrete type T. It is equivalent to calling BindAsNamed with an empty name
+// argument.
+func BindAs[I, T any](c *Container) {
+	bindAs[I, T](c, anonymous, callerSource(0))
+}
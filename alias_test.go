@@ -0,0 +1,67 @@
+package ioc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindAs(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindAs[io.Writer, *bytes.Buffer](c)
+	BindAs[fmt.Stringer, *bytes.Buffer](c)
+	Bind(c, Singleton(Infallible(func(*Container) *bytes.Buffer {
+		return &bytes.Buffer{}
+	})))
+
+	buf := Resolve[*bytes.Buffer](c)
+	assert.Same(t, buf, Resolve[io.Writer](c))
+	assert.Same(t, buf, Resolve[fmt.Stringer](c))
+}
+
+func TestBindAs_Panics(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	assert.PanicsWithValue(t, "ioc.BindAs: *bytes.Buffer is not an interface type", func() {
+		BindAs[*bytes.Buffer, *bytes.Buffer](c)
+	})
+	assert.PanicsWithValue(t, "ioc.BindAs: bytes.Buffer does not implement io.Writer", func() {
+		BindAs[io.Writer, bytes.Buffer](c)
+	})
+
+	c.Freeze()
+	assert.Panics(t, func() { BindAs[io.Writer, *bytes.Buffer](c) })
+}
+
+func TestBindAs_Missing(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindAs[io.Writer, *bytes.Buffer](c)
+
+	_, err := TryResolve[io.Writer](c)
+	resErr := ResolveError{}
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, []typeName{newTypeName[io.Writer](anonymous)}, resErr.chain)
+	assert.ErrorAs(t, err, &MissingProviderError{})
+}
+
+func TestBindAsNamed(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	c := new(Container)
+	BindNamed(c, "foo", Static(buf))
+	BindAsNamed[io.Writer, *bytes.Buffer](c, "foo")
+
+	assert.Same(t, buf, ResolveNamed[io.Writer](c, "foo"))
+	assert.False(t, Has[io.Writer](c))
+}
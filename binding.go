@@ -0,0 +1,226 @@
+package ioc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Binding describes a ProviderFunc bound to a Container or one of its
+// ancestors in the Extend chain.
+type Binding struct {
+	// Type is the type of value produced by the provider.
+	Type reflect.Type
+	// Name is the name the provider is bound with, empty if anonymous.
+	Name string
+	// Qualifier is the qualifier type the provider is bound with via
+	// BindQualified, nil otherwise.
+	Qualifier reflect.Type
+	// Depth is the number of Extend levels between the inspected Container
+	// and the Container defining the binding. Bindings on the inspected
+	// Container itself have a depth of zero.
+	Depth int
+	// Default is true if the binding was made via BindDefault or
+	// BindDefaultNamed.
+	Default bool
+	// Source is the file:line call site that bound the provider.
+	Source string
+	// Kind describes the provider: "singleton", "static", or "infallible" if
+	// created by the helper of the same name, "alias" if bound via BindAs or
+	// BindAsNamed, "reloadable" if bound via BindReloadable or
+	// BindReloadableNamed, and "provider" otherwise.
+	Kind string
+	// Module is the name of the Module that bound the provider, if any.
+	Module string
+	// Condition describes the Condition that activated the provider, if it
+	// was bound via BindIf or BindNamedIf.
+	Condition string
+	// Shadowed is true if another binding for the same type and name takes
+	// precedence over this one when resolving from the inspected Container.
+	Shadowed bool
+}
+
+func newBinding(tn typeName, reg registration) Binding {
+	return Binding{
+		Type:      tn.Type,
+		Name:      tn.Name,
+		Qualifier: tn.Qualifier,
+		Source:    reg.source,
+		Kind:      reg.kind,
+		Module:    reg.module,
+		Condition: reg.condition,
+	}
+}
+
+func (b Binding) typeName() typeName {
+	return typeName{Name: b.Name, Qualifier: b.Qualifier, Type: b.Type}
+}
+
+func (b Binding) String() string {
+	builder := &strings.Builder{}
+	_, _ = fmt.Fprintf(builder, "%v bound at %s (depth %d", b.typeName(), b.Source, b.Depth)
+	b.writeAttributes(builder)
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// writeAttributes writes the optional attributes of the Binding to builder,
+// each preceded by a comma.
+func (b Binding) writeAttributes(builder *strings.Builder) {
+	if b.Module != "" {
+		_, _ = fmt.Fprintf(builder, ", module %s", b.Module)
+	}
+	if b.Condition != "" {
+		_, _ = fmt.Fprintf(builder, ", if %s", b.Condition)
+	}
+	if b.Default {
+		builder.WriteString(", default")
+	}
+	if b.Shadowed {
+		builder.WriteString(", shadowed")
+	}
+}
+
+// levels returns the Containers in the Extend chain, starting with the nearest,
+// excluding any in-flight resolutions of providers and Containers passed to a
+// Module's Configure function.
+func (c *Container) levels() []*Container {
+	var out []*Container
+	for rc := c; rc != nil; rc = rc.parent {
+		if rc.resolving == (typeName{}) && rc.module == nil {
+			out = append(out, rc)
+		}
+	}
+	return out
+}
+
+// Bindings returns a description of every provider bound to the Container and
+// its ancestors in the Extend chain, including those shadowed by another
+// binding of the same type and name. The returned slice is ordered by depth,
+// then by type and name.
+func (c *Container) Bindings() []Binding {
+	var out []Binding
+	active := map[typeName]bool{}
+
+	levels := c.levels()
+	for _, isDefault := range []bool{false, true} {
+		for depth, rc := range levels {
+			providers := &rc.providers
+			if isDefault {
+				providers = &rc.defaults
+			}
+
+			providers.Range(func(tn typeName, reg registration) bool {
+				b := newBinding(tn, reg)
+				b.Depth = depth
+				b.Default = isDefault
+				b.Shadowed = active[tn]
+				out = append(out, b)
+				return true
+			})
+
+			// mark after ranging so that bindings at the same level are not
+			// considered shadowing each other
+			for i := range out {
+				active[out[i].typeName()] = true
+			}
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		switch {
+		case a.Depth != b.Depth:
+			return a.Depth < b.Depth
+		case a.Default != b.Default:
+			return !a.Default
+		case a.Type.String() != b.Type.String():
+			return a.Type.String() < b.Type.String()
+		case a.Type.PkgPath() != b.Type.PkgPath():
+			return a.Type.PkgPath() < b.Type.PkgPath()
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		default:
+			return fmt.Sprint(a.Qualifier) < fmt.Sprint(b.Qualifier)
+		}
+	})
+
+	return out
+}
+
+// Snapshot renders every binding returned by Bindings as a line of text,
+// suitable for committing as a golden file to catch unintended changes to the
+// bindings of a Container in code review. Each line includes the type and name,
+// the Kind of provider, and the file:line that bound it, followed by its depth
+// and the other attributes reported by Binding.String. The source file is
+// relative to the root of the Go module containing it, so the output does not
+// depend on the location of the module on disk.
+func (c *Container) Snapshot() string {
+	roots := map[string]string{}
+	builder := &strings.Builder{}
+	for _, b := range c.Bindings() {
+		_, _ = fmt.Fprintf(builder, "%v %s at %s (depth %d", b.typeName(), b.Kind, moduleRelative(b.Source, roots), b.Depth)
+		b.writeAttributes(builder)
+		builder.WriteString(")\n")
+	}
+	return builder.String()
+}
+
+// moduleRelative returns source, a file:line, with the file made relative to
+// the nearest ancestor directory containing a go.mod file. The root found for
+// each directory is cached in roots. If there is no such directory, as is the
+// case for binaries built with -trimpath, source is returned unchanged.
+func moduleRelative(source string, roots map[string]string) string {
+	i := strings.LastIndexByte(source, ':')
+	if i < 0 || !filepath.IsAbs(source[:i]) {
+		return source
+	}
+
+	file, dir := source[:i], filepath.Dir(source[:i])
+	root, ok := roots[dir]
+	if !ok {
+		root = moduleRoot(dir)
+		roots[dir] = root
+	}
+	if root == "" {
+		return source
+	}
+
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return source
+	}
+	return filepath.ToSlash(rel) + source[i:]
+}
+
+// moduleRoot returns the nearest ancestor of dir (including dir itself) that
+// contains a go.mod file, or an empty string if there is none.
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// HasNamed reports whether a provider can be found for type T with the
+// specified name, without resolving it.
+func HasNamed[T any](c *Container, name string) bool {
+	_, err := findProvider[T](c, newTypeName[T](name))
+	return err == nil
+}
+
+// Has reports whether a provider can be found for type T, without resolving
+// it. It is equivalent to calling HasNamed with an empty name argument.
+func Has[T any](c *Container) bool {
+	return HasNamed[T](c, anonymous)
+}
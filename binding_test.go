@@ -0,0 +1,171 @@
+package ioc
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Bindings(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	Bind(parent, Static(123))
+	BindNamed(parent, "foo", Static("bar"))
+	BindDefault(parent, Static(true))
+	parent.Freeze()
+
+	child := parent.Extend()
+	Bind(child, Static(456))
+	BindDefaultNamed(child, "foo", Static("baz"))
+
+	intType := reflect.TypeOf(0)
+	stringType := reflect.TypeOf("")
+	boolType := reflect.TypeOf(true)
+
+	bindings := child.Bindings()
+	for i := range bindings {
+		assert.Contains(t, bindings[i].Source, "binding_test.go:")
+		bindings[i].Source = ""
+	}
+
+	assert.Equal(t, []Binding{
+		{Type: intType, Kind: "static", Depth: 0},
+		{Type: stringType, Name: "foo", Kind: "static", Depth: 0, Default: true, Shadowed: true},
+		{Type: intType, Kind: "static", Depth: 1, Shadowed: true},
+		{Type: stringType, Name: "foo", Kind: "static", Depth: 1},
+		{Type: boolType, Kind: "static", Depth: 1, Default: true},
+	}, bindings)
+
+	bindings = parent.Bindings()
+	for i := range bindings {
+		bindings[i].Source = ""
+	}
+
+	assert.Equal(t, []Binding{
+		{Type: intType, Kind: "static", Depth: 0},
+		{Type: stringType, Name: "foo", Kind: "static", Depth: 0},
+		{Type: boolType, Kind: "static", Depth: 0, Default: true},
+	}, bindings)
+
+	assert.Empty(t, new(Container).Bindings())
+}
+
+func TestContainer_Bindings_Resolving(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+
+	var bindings []Binding
+	Bind(c, Infallible(func(c *Container) string {
+		bindings = c.Bindings()
+		return "foo"
+	}))
+
+	Resolve[string](c)
+	assert.Len(t, bindings, 2)
+	for _, b := range bindings {
+		assert.Zero(t, b.Depth, "should not count resolving containers")
+	}
+}
+
+func TestBinding_String(t *testing.T) {
+	t.Parallel()
+
+	b := Binding{
+		Type:     reflect.TypeOf((*io.Writer)(nil)).Elem(),
+		Name:     "w",
+		Depth:    2,
+		Default:  true,
+		Source:   "foo.go:12",
+		Module:   "bar",
+		Shadowed: true,
+	}
+	assert.Equal(t, "io.Writer:w bound at foo.go:12 (depth 2, module bar, default, shadowed)", b.String())
+
+	b = Binding{Type: reflect.TypeOf(0), Source: "bar.go:34"}
+	assert.Equal(t, "int bound at bar.go:34 (depth 0)", b.String())
+}
+
+func TestHas(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+	BindDefault(c, Static("foo"))
+
+	assert.True(t, Has[int](c))
+	assert.True(t, Has[string](c))
+	assert.False(t, Has[bool](c))
+	assert.True(t, Has[Lazy[bool]](c), "should include implicit bindings")
+	assert.True(t, Has[int](c.Extend()))
+}
+
+func TestHasNamed(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindNamed(c, "foo", Static(123))
+
+	assert.True(t, HasNamed[int](c, "foo"))
+	assert.False(t, HasNamed[int](c, "bar"))
+	assert.False(t, Has[int](c))
+}
+
+func TestContainer_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	Bind(parent, Static(123))
+	BindDefaultNamed(parent, "foo", Singleton(func(*Container) (string, error) { return "bar", nil }))
+	parent.Freeze()
+
+	child := parent.Extend()
+	Install(child, &Module{
+		Name:      "mod",
+		Configure: func(c *Container) { Bind(c, Infallible(func(*Container) int { return 456 })) },
+	})
+	BindAs[io.Reader, *strings.Reader](child)
+
+	lines := strings.Split(child.Snapshot(), "\n")
+	require.Len(t, lines, 5)
+	assert.Regexp(t, `^int infallible at binding_test\.go:\d+ \(depth 0, module mod\)$`, lines[0])
+	assert.Regexp(t, `^io\.Reader alias at binding_test\.go:\d+ \(depth 0\)$`, lines[1])
+	assert.Regexp(t, `^int static at binding_test\.go:\d+ \(depth 1, shadowed\)$`, lines[2])
+	assert.Regexp(t, `^string:foo singleton at binding_test\.go:\d+ \(depth 1, default\)$`, lines[3])
+	assert.Empty(t, lines[4])
+
+	assert.Equal(t, child.Snapshot(), child.Snapshot(), "should be deterministic")
+	assert.Empty(t, new(Container).Snapshot())
+}
+
+func TestModuleRelative(t *testing.T) {
+	t.Parallel()
+
+	_, file, _, _ := runtime.Caller(0)
+	outside := filepath.Join(t.TempDir(), "main.go")
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"module root", file + ":12", "binding_test.go:12"},
+		{"subdirectory", filepath.Join(filepath.Dir(file), "ioctest", "spy.go") + ":34", "ioctest/spy.go:34"},
+		{"no module", outside + ":56", outside + ":56"},
+		{"trimmed", "github.com/rodaine/ioc/binding_test.go:78", "github.com/rodaine/ioc/binding_test.go:78"},
+		{"unknown", "unknown", "unknown"},
+	}
+
+	roots := map[string]string{}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, moduleRelative(test.source, roots), test.name)
+	}
+}
@@ -0,0 +1,59 @@
+package ioc
+
+// OnClose registers fn to be called when the Container is closed via Close.
+// Providers can use OnClose to release the resources held by the values they
+// create, such as network connections or background goroutines. If the
+// Container has already been closed, fn is called immediately.
+//
+// Hooks registered from within a Singleton provider (or any provider it
+// resolves) are attached to the Container the Singleton is bound to, rather
+// than the one it was resolved from. This ensures a Singleton bound to a
+// shared parent is only released when that parent is closed, not when an
+// extending Container that happened to resolve it first is closed. Hooks
+// registered by other providers create a new value on each resolution, so they
+// are attached to the Container the value was resolved from.
+func (c *Container) OnClose(fn func() error) {
+	c = c.closer()
+
+	c.closeMu.Lock()
+	if !c.closed {
+		c.closers = append(c.closers, fn)
+		c.closeMu.Unlock()
+		return
+	}
+	c.closeMu.Unlock()
+
+	_ = fn()
+}
+
+// Close calls the functions registered via OnClose in the reverse order they
+// were registered, returning the first error encountered. All functions are
+// called regardless of errors. Close does not close the Container's parent,
+// and is idempotent; subsequent calls return nil.
+func (c *Container) Close() error {
+	c.closeMu.Lock()
+	closers := c.closers
+	c.closers, c.closed = nil, true
+	c.closeMu.Unlock()
+
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if cErr := closers[i](); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	return err
+}
+
+// closer returns the Container that hooks registered via OnClose on c are
+// attached to: the Container owning the nearest Singleton being resolved by c,
+// if any, or else the nearest Container that is not an in-flight resolution.
+func (c *Container) closer() *Container {
+	for rc := c; rc != nil && rc.resolving != (typeName{}); rc = rc.parent {
+		if rc.owner != nil {
+			return rc.owner
+		}
+	}
+	return c.unwind().target()
+}
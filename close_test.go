@@ -0,0 +1,101 @@
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Close(t *testing.T) {
+	t.Parallel()
+
+	errA, errB := errors.New("a"), errors.New("b")
+
+	var order []string
+	c := new(Container)
+	c.OnClose(func() error { order = append(order, "a"); return errA })
+	c.OnClose(func() error { order = append(order, "b"); return errB })
+	c.OnClose(func() error { order = append(order, "c"); return nil })
+
+	assert.ErrorIs(t, c.Close(), errB)
+	assert.Equal(t, []string{"c", "b", "a"}, order)
+
+	assert.NoError(t, c.Close(), "should be idempotent")
+	assert.Equal(t, []string{"c", "b", "a"}, order)
+
+	c.OnClose(func() error { order = append(order, "d"); return nil })
+	assert.Equal(t, []string{"c", "b", "a", "d"}, order, "should call immediately once closed")
+}
+
+func TestContainer_OnClose_Provider(t *testing.T) {
+	t.Parallel()
+
+	closed := false
+	c := new(Container)
+	Bind(c, Singleton(func(c *Container) (*int, error) {
+		c.OnClose(func() error { closed = true; return nil })
+		v := 123
+		return &v, nil
+	}))
+
+	child := c.Extend()
+	v := Resolve[*int](child)
+
+	assert.NoError(t, child.Close())
+	assert.False(t, closed, "should attach to the container owning the binding")
+	assert.Same(t, v, Resolve[*int](c.Extend()))
+
+	assert.NoError(t, c.Close())
+	assert.True(t, closed)
+}
+
+func TestContainer_OnClose_ProviderOverride(t *testing.T) {
+	t.Parallel()
+
+	closed := false
+	c := new(Container)
+	Bind(c, Static(123))
+
+	child := c.Extend()
+	Bind(child, Singleton(func(c *Container) (int, error) {
+		c.OnClose(func() error { closed = true; return nil })
+		return 456, nil
+	}))
+	assert.Equal(t, 456, Resolve[int](child.Extend()))
+
+	assert.NoError(t, c.Close())
+	assert.False(t, closed)
+
+	assert.NoError(t, child.Close())
+	assert.True(t, closed)
+}
+
+func TestContainer_OnClose_Transient(t *testing.T) {
+	t.Parallel()
+
+	closed := 0
+	c := new(Container)
+	BindNamed(c, "transient", Infallible(func(c *Container) int {
+		c.OnClose(func() error { closed++; return nil })
+		return 123
+	}))
+	Bind(c, Singleton(func(c *Container) (string, error) {
+		return fmt.Sprint(ResolveNamed[int](c, "transient")), nil
+	}))
+
+	const n = 10
+	child := c.Extend()
+	for i := 0; i < n; i++ {
+		ResolveNamed[int](child, "transient")
+	}
+	assert.Equal(t, "123", Resolve[string](child))
+	assert.Len(t, child.closers, n, "should attach to the resolving container")
+	assert.Len(t, c.closers, 1, "should attach to the container owning the Singleton")
+
+	assert.NoError(t, child.Close())
+	assert.Equal(t, n, closed)
+	assert.NoError(t, c.Close())
+	assert.Equal(t, n+1, closed)
+}
@@ -0,0 +1,209 @@
+package ioc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Condition determines whether a binding made via BindIf or BindNamedIf is
+// activated. Conditions are evaluated once, when the Container the binding was
+// made on is frozen.
+type Condition struct {
+	desc string
+	eval func(c *Container) (active bool, reason string)
+}
+
+func (cond Condition) String() string {
+	return cond.desc
+}
+
+// When creates a Condition that is active if fn returns true. The description
+// is used to report why the Condition was or was not activated.
+func When(desc string, fn func() bool) Condition {
+	return Condition{
+		desc: desc,
+		eval: func(*Container) (bool, string) {
+			if fn() {
+				return true, desc
+			}
+			return false, "not " + desc
+		},
+	}
+}
+
+// WithProfile creates a Condition that is active if any of the specified
+// profiles have been activated on the Container (or one of its ancestors) via
+// ActivateProfiles.
+func WithProfile(profiles ...string) Condition {
+	return Condition{
+		desc: fmt.Sprintf("profile in %v", profiles),
+		eval: func(c *Container) (bool, string) {
+			for _, p := range profiles {
+				if c.profileActive(p) {
+					return true, fmt.Sprintf("profile %q is active", p)
+				}
+			}
+			return false, fmt.Sprintf("none of profiles %v are active (active: %v)",
+				profiles, c.Profiles())
+		},
+	}
+}
+
+// WithEnv creates a Condition that is active if the environment variable key
+// is set to a non-empty value.
+func WithEnv(key string) Condition {
+	return Condition{
+		desc: fmt.Sprintf("$%s is set", key),
+		eval: func(*Container) (bool, string) {
+			if os.Getenv(key) != "" {
+				return true, fmt.Sprintf("$%s is set", key)
+			}
+			return false, fmt.Sprintf("$%s is not set", key)
+		},
+	}
+}
+
+// ConditionalBinding describes a binding made via BindIf or BindNamedIf, and
+// the outcome of evaluating its Condition.
+type ConditionalBinding struct {
+	Binding
+	// Evaluated is true if the Container defining the binding has been frozen
+	// and the Condition has been evaluated.
+	Evaluated bool
+	// Active is true if the Condition was met and the binding was activated.
+	Active bool
+	// Reason explains why the binding was or was not activated.
+	Reason string
+}
+
+func (cb ConditionalBinding) String() string {
+	switch {
+	case !cb.Evaluated:
+		return fmt.Sprintf("%v: pending (%s)", cb.Binding, cb.Condition)
+	case cb.Active:
+		return fmt.Sprintf("%v: active (%s)", cb.Binding, cb.Reason)
+	default:
+		return fmt.Sprintf("%v: inactive (%s)", cb.Binding, cb.Reason)
+	}
+}
+
+type conditional struct {
+	name      typeName
+	reg       registration
+	cond      Condition
+	evaluated bool
+	active    bool
+	reason    string
+}
+
+// ActivateProfiles marks the specified profiles as active on the Container and
+// any Containers extending it, for use with WithProfile conditions. Profiles
+// should be activated before the Container is frozen.
+func (c *Container) ActivateProfiles(profiles ...string) {
+	c = c.target()
+	for _, p := range profiles {
+		c.profiles.Store(p, struct{}{})
+	}
+}
+
+// Profiles returns the sorted names of the profiles activated on the Container
+// and its ancestors in the Extend chain.
+func (c *Container) Profiles() []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, rc := range c.levels() {
+		rc.profiles.Range(func(p string, _ struct{}) bool {
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, p)
+			}
+			return true
+		})
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *Container) profileActive(profile string) bool {
+	for _, rc := range c.levels() {
+		if _, ok := rc.profiles.Load(profile); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// activate evaluates the conditional bindings on the Container, binding those
+// whose Condition is met.
+func (c *Container) activate() {
+	c.condMu.Lock()
+	defer c.condMu.Unlock()
+
+	for _, cb := range c.conditionals {
+		cb.evaluated = true
+		cb.active, cb.reason = cb.cond.eval(c)
+		if cb.active {
+			c.bind(cb.name, cb.reg, false)
+		}
+	}
+}
+
+// Conditions returns a description of every conditional binding made on the
+// Container and its ancestors in the Extend chain, in the order they were
+// bound, starting with the nearest Container.
+func (c *Container) Conditions() []ConditionalBinding {
+	var out []ConditionalBinding
+	for depth, rc := range c.levels() {
+		rc.condMu.Lock()
+		for _, cb := range rc.conditionals {
+			b := newBinding(cb.name, cb.reg)
+			b.Depth = depth
+			out = append(out, ConditionalBinding{
+				Binding:   b,
+				Evaluated: cb.evaluated,
+				Active:    cb.active,
+				Reason:    cb.reason,
+			})
+		}
+		rc.condMu.Unlock()
+	}
+	return out
+}
+
+func bindIf(c *Container, name typeName, cond Condition, reg registration) {
+	t := c.target()
+	t.checkFrozen()
+	c.attribute(&reg)
+	reg.condition = cond.desc
+	reg.owner = t
+	reg.deps = &dependencySet{}
+
+	t.condMu.Lock()
+	defer t.condMu.Unlock()
+
+	t.conditionals = append(t.conditionals, &conditional{
+		name: name,
+		reg:  reg,
+		cond: cond,
+	})
+}
+
+// BindNamedIf associates a ProviderFunc with the specified name and type if
+// cond is met. The Condition is evaluated when the Container is frozen, and
+// the provider is not resolvable until then. An activated conditional binding
+// replaces a provider bound via BindNamed for the same type and name, unless
+// the Container is in strict mode, in which case Freeze panics with a
+// DuplicateBindingError. The outcome of each Condition is reported by
+// Container.Conditions.
+func BindNamedIf[T any](c *Container, name string, cond Condition, fn ProviderFunc[T]) {
+	bindIf(c, newTypeName[T](name), cond, newRegistration(fn, 0))
+}
+
+// BindIf associates a ProviderFunc with the specified type anonymously if cond
+// is met. It is equivalent to calling BindNamedIf with an empty name argument.
+func BindIf[T any](c *Container, cond Condition, fn ProviderFunc[T]) {
+	bindIf(c, newTypeName[T](anonymous), cond, newRegistration(fn, 0))
+}
+
+var _ fmt.Stringer = Condition{}
@@ -0,0 +1,119 @@
+package ioc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindIf(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.ActivateProfiles("dev")
+	Bind(c, Static("prod"))
+	BindIf(c, WithProfile("dev", "test"), Static("dev"))
+	BindIf(c, When("flag enabled", func() bool { return false }), Static(123))
+
+	assert.Equal(t, "prod", Resolve[string](c), "should not activate before Freeze")
+	assert.False(t, Has[int](c))
+
+	c.Freeze()
+	assert.Equal(t, "dev", Resolve[string](c))
+	assert.False(t, Has[int](c))
+
+	assert.Panics(t, func() { BindIf(c, When("x", func() bool { return true }), Static(true)) })
+
+	bindings := c.Bindings()
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "profile in [dev test]", bindings[0].Condition)
+}
+
+func TestBindNamedIf(t *testing.T) {
+	t.Setenv("IOC_TEST_BIND_NAMED_IF", "1")
+
+	c := new(Container)
+	BindNamedIf(c, "foo", WithEnv("IOC_TEST_BIND_NAMED_IF"), Static(123))
+	BindNamedIf(c, "bar", WithEnv("IOC_TEST_BIND_NAMED_IF_UNSET"), Static(456))
+	c.Freeze()
+
+	assert.Equal(t, 123, ResolveNamed[int](c, "foo"))
+	assert.False(t, HasNamed[int](c, "bar"))
+}
+
+func TestBindIf_Strict(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+	Bind(c, Static(123))
+	BindIf(c, When("always", func() bool { return true }), Static(456))
+
+	assert.Panics(t, c.Freeze)
+	assert.Panics(t, func() { Bind(c, Static("foo")) }, "should still freeze")
+}
+
+func TestContainer_Conditions(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	parent.ActivateProfiles("test")
+	BindIf(parent, WithProfile("test"), Static(123))
+	parent.Freeze()
+
+	child := parent.Extend()
+	child.ActivateProfiles("dev")
+	BindNamedIf(child, "foo", WithProfile("prod"), Static(456))
+	BindNamedIf(child, "bar", When("enabled", func() bool { return true }), Static(789))
+
+	conds := child.Conditions()
+	require.Len(t, conds, 3)
+	assert.False(t, conds[0].Evaluated)
+	assert.Contains(t, conds[0].String(), "int:foo bound at ")
+	assert.Contains(t, conds[0].String(), ": pending (profile in [prod])")
+
+	child.Freeze()
+	conds = child.Conditions()
+	require.Len(t, conds, 3)
+
+	assert.Equal(t, "foo", conds[0].Name)
+	assert.True(t, conds[0].Evaluated)
+	assert.False(t, conds[0].Active)
+	assert.Equal(t, "none of profiles [prod] are active (active: [dev test])", conds[0].Reason)
+	assert.Contains(t, conds[0].String(), ": inactive (none of profiles")
+
+	assert.Equal(t, "bar", conds[1].Name)
+	assert.True(t, conds[1].Active)
+	assert.Equal(t, "enabled", conds[1].Reason)
+
+	assert.Equal(t, 1, conds[2].Depth)
+	assert.True(t, conds[2].Active)
+	assert.Equal(t, `profile "test" is active`, conds[2].Reason)
+	assert.Contains(t, conds[2].String(), `: active (profile "test" is active)`)
+}
+
+func TestContainer_Profiles(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	parent.ActivateProfiles("b", "a")
+
+	child := parent.Extend()
+	child.ActivateProfiles("c", "a")
+
+	assert.Equal(t, []string{"a", "b"}, parent.Profiles())
+	assert.Equal(t, []string{"a", "b", "c"}, child.Profiles())
+	assert.Empty(t, new(Container).Profiles())
+}
+
+func TestWhen(t *testing.T) {
+	t.Parallel()
+
+	cond := When("enabled", func() bool { return false })
+	assert.Equal(t, "enabled", cond.String())
+
+	active, reason := cond.eval(new(Container))
+	assert.False(t, active)
+	assert.Equal(t, "not enabled", reason)
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/rodaine/ioc"
+	"github.com/rodaine/ioc/internal/callsite"
+	"gopkg.in/yaml.v3"
+)
+
+// Format describes how to decode a configuration document.
+type Format struct {
+	name      string
+	marshal   func(v any) ([]byte, error)
+	unmarshal func(data []byte, v any) error
+	// unmarshalDoc decodes into an untyped value, preserving the precision of
+	// numbers so sections can be re-encoded without loss.
+	unmarshalDoc func(data []byte, v any) error
+	// key returns the document key for a struct field, or inline if the
+	// field's own fields are decoded from the enclosing object.
+	key func(sf reflect.StructField) (name string, inline bool)
+	// fold is true if keys are matched case-insensitively.
+	fold bool
+}
+
+func (f Format) String() string {
+	return f.name
+}
+
+var (
+	// JSON decodes documents with encoding/json. Fields are matched using the
+	// `json` struct tag.
+	JSON = Format{
+		name:         "json",
+		marshal:      json.Marshal,
+		unmarshal:    json.Unmarshal,
+		unmarshalDoc: unmarshalJSONDoc,
+		key:          jsonKey,
+		fold:         true,
+	}
+	// YAML decodes documents with gopkg.in/yaml.v3. Fields are matched using
+	// the `yaml` struct tag.
+	YAML = Format{
+		name:         "yaml",
+		marshal:      yaml.Marshal,
+		unmarshal:    yaml.Unmarshal,
+		unmarshalDoc: yaml.Unmarshal,
+		key:          yamlKey,
+	}
+)
+
+func jsonKey(sf reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" {
+		return sf.Name, sf.Anonymous
+	}
+	return name, false
+}
+
+func yamlKey(sf reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	if name == "" {
+		name = strings.ToLower(sf.Name)
+	}
+	return name, strings.Contains(","+opts+",", ",inline,")
+}
+
+// present reports whether doc, a document decoded into an untyped value,
+// contains a value for the field at path.
+func (f Format) present(doc any, path []reflect.StructField) bool {
+	for _, sf := range path {
+		name, inline := f.key(sf)
+		if inline {
+			continue
+		}
+
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return false
+		}
+		if doc, ok = f.lookup(m, name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the value for key in m, an object from a document decoded
+// into an untyped value. Like the Format's decoder, keys are matched
+// case-insensitively if the Format folds case, preferring an exact match.
+func (f Format) lookup(m map[string]any, key string) (any, bool) {
+	if value, ok := m[key]; ok || !f.fold {
+		return value, ok
+	}
+
+	for k, value := range m {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// unmarshalJSONDoc behaves like json.Unmarshal, but decodes numbers in untyped
+// values as json.Number to preserve their precision when extracting sections.
+func unmarshalJSONDoc(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+// Source provides the raw contents of a configuration document.
+type Source func() ([]byte, error)
+
+// File creates a Source that reads the file at path each time it is called.
+func File(path string) Source {
+	return func() ([]byte, error) {
+		return os.ReadFile(path)
+	}
+}
+
+// Reader creates a Source that reads r to completion on its first call. The
+// contents are cached, so the Source can be shared by multiple bindings.
+func Reader(r io.Reader) Source {
+	var data []byte
+	var err error
+	once := &sync.Once{}
+
+	return func() ([]byte, error) {
+		once.Do(func() { data, err = io.ReadAll(r) })
+		return data, err
+	}
+}
+
+// LoadSection decodes the section of the document from src with the dotted
+// path section (eg, "database" or "database.replica") into a T. An empty
+// section decodes the whole document. Keys in the path are matched the same way
+// the Format matches field names: case-insensitively for JSON, and exactly for
+// YAML. If the section does not exist, a RequiredError is returned.
+func LoadSection[T any](format Format, src Source, section string) (out T, err error) {
+	data, err := src()
+	if err != nil {
+		return out, err
+	}
+
+	if section != "" {
+		if data, err = extract(format, data, section); err != nil {
+			return out, err
+		}
+	}
+
+	if err = format.unmarshal(data, &out); err != nil {
+		return out, fmt.Errorf("decoding %v config: %w", format, err)
+	}
+
+	return out, nil
+}
+
+// Load decodes the document from src into a T. It is equivalent to calling
+// LoadSection with an empty section argument.
+func Load[T any](format Format, src Source) (T, error) {
+	return LoadSection[T](format, src, "")
+}
+
+// extract returns the encoded contents of the dotted section path of data.
+func extract(format Format, data []byte, section string) ([]byte, error) {
+	var doc any
+	if err := format.unmarshalDoc(data, &doc); err != nil {
+		return nil, fmt.Errorf("decoding %v config: %w", format, err)
+	}
+
+	for _, key := range strings.Split(section, ".") {
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil, RequiredError{Keys: []string{section}}
+		}
+		if doc, ok = format.lookup(m, key); !ok {
+			return nil, RequiredError{Keys: []string{section}}
+		}
+	}
+
+	return format.marshal(doc)
+}
+
+// BindSection binds a Singleton provider for T, named after section, that is
+// decoded from the section of the document from src via LoadSection. Errors
+// loading T are returned when it is first resolved.
+//
+//	src := config.File("config.yaml")
+//	config.Bind[AppConfig](c, config.YAML, src)
+//	config.BindSection[DBConfig](c, config.YAML, src, "database")
+func BindSection[T any](c *ioc.Container, format Format, src Source, section string) {
+	callsite.Helper()
+	ioc.BindNamed(c, section, ioc.Singleton(func(*ioc.Container) (T, error) {
+		return LoadSection[T](format, src, section)
+	}))
+}
+
+// Bind binds an anonymous Singleton provider for T that is decoded from the
+// document from src. It is equivalent to calling BindSection with an empty
+// section argument.
+func Bind[T any](c *ioc.Container, format Format, src Source) {
+	callsite.Helper()
+	BindSection[T](c, format, src, "")
+}
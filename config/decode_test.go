@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dbConfig struct {
+	DSN      string `json:"dsn" yaml:"dsn"`
+	MaxConns int64  `json:"max_conns" yaml:"max_conns"`
+}
+
+type appConfig struct {
+	Name     string   `json:"name" yaml:"name"`
+	Database dbConfig `json:"database" yaml:"database"`
+}
+
+const (
+	jsonDoc = `{
+	"name": "app",
+	"database": {"dsn": "postgres://", "max_conns": 9007199254740993},
+	"replica": {"primary": {"dsn": "mysql://"}}
+}`
+	yamlDoc = `
+name: app
+database:
+  dsn: postgres://
+  max_conns: 9007199254740993
+replica:
+  primary:
+    dsn: mysql://
+`
+)
+
+func TestLoadSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format Format
+		doc    string
+	}{
+		{format: JSON, doc: jsonDoc},
+		{format: YAML, doc: yamlDoc},
+	}
+
+	for _, test := range tests {
+		format, doc := test.format, test.doc
+		t.Run(format.String(), func(t *testing.T) {
+			t.Parallel()
+
+			src := Reader(strings.NewReader(doc))
+
+			app, err := Load[appConfig](format, src)
+			require.NoError(t, err)
+			assert.Equal(t, "app", app.Name)
+			assert.Equal(t, int64(9007199254740993), app.Database.MaxConns)
+
+			db, err := LoadSection[dbConfig](format, src, "database")
+			require.NoError(t, err)
+			assert.Equal(t, app.Database, db, "should preserve precision")
+
+			db, err = LoadSection[dbConfig](format, src, "replica.primary")
+			require.NoError(t, err)
+			assert.Equal(t, "mysql://", db.DSN)
+
+			_, err = LoadSection[dbConfig](format, src, "cache")
+			assert.ErrorAs(t, err, &RequiredError{})
+
+			_, err = LoadSection[dbConfig](format, src, "name.dsn")
+			assert.ErrorAs(t, err, &RequiredError{})
+		})
+	}
+}
+
+func TestLoadSection_Case(t *testing.T) {
+	t.Parallel()
+
+	src := Reader(strings.NewReader(`{"server": {"dsn": "lower"}, "Replica": {"dsn": "upper"}, "replica": {"dsn": "exact"}}`))
+
+	db, err := LoadSection[dbConfig](JSON, src, "Server")
+	require.NoError(t, err, "should match sections case-insensitively like json.Unmarshal")
+	assert.Equal(t, "lower", db.DSN)
+
+	db, err = LoadSection[dbConfig](JSON, src, "replica")
+	require.NoError(t, err)
+	assert.Equal(t, "exact", db.DSN, "should prefer an exact match")
+
+	_, err = LoadSection[dbConfig](YAML, Reader(strings.NewReader("server:\n  dsn: lower\n")), "Server")
+	assert.ErrorAs(t, err, &RequiredError{}, "should match sections exactly like yaml.Unmarshal")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load[appConfig](JSON, File(filepath.Join(t.TempDir(), "missing.json")))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load[appConfig](JSON, Reader(strings.NewReader("{")))
+	assert.ErrorContains(t, err, "decoding json config")
+
+	_, err = LoadSection[appConfig](YAML, Reader(strings.NewReader(":")), "database")
+	assert.ErrorContains(t, err, "decoding yaml config")
+
+	_, err = Load[appConfig](JSON, Reader(strings.NewReader(`{"name": "app"} garbage`)))
+	assert.ErrorContains(t, err, "decoding json config")
+
+	_, err = LoadSection[dbConfig](JSON, Reader(strings.NewReader(`{"database": {}} {}`)), "database")
+	assert.ErrorContains(t, err, "decoding json config")
+}
+
+func TestLoad_Untyped(t *testing.T) {
+	t.Parallel()
+
+	type untyped struct {
+		Value any            `json:"value"`
+		Extra map[string]any `json:"extra"`
+	}
+
+	cfg, err := Load[untyped](JSON, Reader(strings.NewReader(`{"value": 1.5, "extra": {"n": 2}}`)))
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, cfg.Value, "should decode numbers as float64")
+	assert.Equal(t, map[string]any{"n": 2.0}, cfg.Extra)
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yamlDoc), 0o600))
+
+	c := new(ioc.Container)
+	Bind[appConfig](c, YAML, File(path))
+	BindSection[dbConfig](c, YAML, File(path), "database")
+	assertSources(t, c, "decode_test.go")
+
+	assert.Equal(t, "app", ioc.Resolve[appConfig](c).Name)
+	assert.Equal(t, "postgres://", ioc.ResolveNamed[dbConfig](c, "database").DSN)
+
+	require.NoError(t, os.WriteFile(path, []byte("name: changed"), 0o600))
+	assert.Equal(t, "app", ioc.Resolve[appConfig](c).Name, "should be a singleton")
+}
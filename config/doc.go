@@ -0,0 +1,5 @@
+// Package config provides ioc providers that build typed configuration structs
+// from external sources, such as environment variables, command-line flags,
+// and JSON or YAML documents. Sources can also be merged in layers of
+// increasing precedence via BindLayered.
+package config
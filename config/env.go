@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+
+	"github.com/rodaine/ioc"
+	"github.com/rodaine/ioc/internal/callsite"
+)
+
+// LoadEnv builds a T from environment variables. T must be a struct type;
+// nested structs are populated recursively. Each field is read from the
+// variable named by prefix, the names of any enclosing struct fields, and the
+// field's own name, joined with underscores. Names are taken from the `env`
+// struct tag, falling back to the field name in SCREAMING_SNAKE_CASE. The
+// following struct tags are also supported:
+//
+//	env:"-"          the field is ignored
+//	default:"value"  the value used if the variable is unset or empty
+//	required:"true"  the variable must be set if there is no default
+//
+// Strings, bools, integers, floats, time.Duration, encoding.TextUnmarshaler
+// implementations, and slices of these (comma-separated) are supported.
+//
+// A RequiredError lists all missing required variables, and a ParseError is
+// returned for the first value that cannot be parsed.
+func LoadEnv[T any](prefix string) (T, error) {
+	var out T
+	_, err := applyEnv(&out, prefix, true)
+	return out, err
+}
+
+// applyEnv sets the fields of the struct pointed to by ptr from environment
+// variables, returning the paths of the fields that were set. If withDefaults
+// is false, default and required tags are ignored and only fields with a set
+// variable are modified.
+func applyEnv(ptr any, prefix string, withDefaults bool) (paths []string, err error) {
+	fs, err := fields(ptr, "env")
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, f := range fs {
+		key := f.Key("env", "_", screamingSnake)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		value := os.Getenv(key)
+		if value == "" && withDefaults {
+			value = f.Tag("default")
+		}
+		if value == "" {
+			if withDefaults && f.Required() {
+				missing = append(missing, key)
+			}
+			continue
+		}
+
+		if err = set(f.value, value); err != nil {
+			return paths, ParseError{Key: key, Value: value, Err: err}
+		}
+		paths = append(paths, f.Path())
+	}
+
+	if len(missing) > 0 {
+		return paths, RequiredError{Keys: missing}
+	}
+
+	return paths, nil
+}
+
+// BindEnvNamed binds a Singleton provider for T with the specified name that
+// is built from environment variables via LoadEnv. Errors loading T are
+// returned when it is first resolved.
+func BindEnvNamed[T any](c *ioc.Container, name, prefix string) {
+	callsite.Helper()
+	ioc.BindNamed(c, name, ioc.Singleton(func(*ioc.Container) (T, error) {
+		return LoadEnv[T](prefix)
+	}))
+}
+
+// BindEnv binds an anonymous Singleton provider for T that is built from
+// environment variables via LoadEnv. It is equivalent to calling BindEnvNamed
+// with an empty name argument.
+func BindEnv[T any](c *ioc.Container, prefix string) {
+	callsite.Helper()
+	BindEnvNamed[T](c, "", prefix)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type envConfig struct {
+	Addr     string        `env:"ADDR" default:":8080"`
+	Timeout  time.Duration `default:"5s"`
+	MaxConns int
+	Tags     []string
+	DB       struct {
+		DSN string `required:"true"`
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("APP_TIMEOUT", "1m")
+	t.Setenv("APP_MAX_CONNS", "12")
+	t.Setenv("APP_TAGS", "a,b")
+	t.Setenv("APP_DB_DSN", "postgres://")
+
+	cfg, err := LoadEnv[envConfig]("APP")
+	require.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Addr)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, 12, cfg.MaxConns)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, "postgres://", cfg.DB.DSN)
+}
+
+func TestLoadEnv_Required(t *testing.T) {
+	t.Setenv("APP_DB_DSN", "")
+
+	_, err := LoadEnv[envConfig]("APP")
+	reqErr := RequiredError{}
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, []string{"APP_DB_DSN"}, reqErr.Keys)
+}
+
+func TestLoadEnv_Invalid(t *testing.T) {
+	t.Setenv("APP_DB_DSN", "postgres://")
+	t.Setenv("APP_MAX_CONNS", "many")
+
+	_, err := LoadEnv[envConfig]("APP")
+	parseErr := ParseError{}
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "APP_MAX_CONNS", parseErr.Key)
+	assert.Equal(t, "many", parseErr.Value)
+
+	_, err = LoadEnv[string]("APP")
+	assert.Error(t, err)
+}
+
+func TestBindEnv(t *testing.T) {
+	t.Setenv("APP_DB_DSN", "postgres://")
+
+	c := new(ioc.Container)
+	BindEnv[envConfig](c, "APP")
+	assertSources(t, c, "env_test.go")
+	cfg := ioc.Resolve[envConfig](c)
+	assert.Equal(t, "postgres://", cfg.DB.DSN)
+
+	t.Setenv("APP_DB_DSN", "mysql://")
+	assert.Equal(t, cfg, ioc.Resolve[envConfig](c), "should be a singleton")
+}
+
+func TestBindEnvNamed(t *testing.T) {
+	t.Setenv("APP_DB_DSN", "")
+
+	c := new(ioc.Container)
+	BindEnvNamed[envConfig](c, "app", "APP")
+	assertSources(t, c, "env_test.go")
+
+	_, err := ioc.TryResolveNamed[envConfig](c, "app")
+	assert.ErrorAs(t, err, &RequiredError{})
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequiredError is returned when configuration values for fields tagged with
+// `required:"true"` are not provided. Keys contains the source-specific names
+// of the missing values, such as environment variable names.
+type RequiredError struct {
+	Keys []string
+}
+
+func (err RequiredError) Error() string {
+	return fmt.Sprintf("missing required configuration: %s", strings.Join(err.Keys, ", "))
+}
+
+// ParseError is returned when a configuration value cannot be parsed into the
+// type of its associated field.
+type ParseError struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (err ParseError) Error() string {
+	return fmt.Sprintf("invalid value %q for %s: %v", err.Value, err.Key, err.Err)
+}
+
+func (err ParseError) Unwrap() error {
+	return err.Err
+}
+
+var (
+	_ error = RequiredError{}
+	_ error = ParseError{}
+)
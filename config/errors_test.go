@@ -0,0 +1,24 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := RequiredError{Keys: []string{"FOO", "BAR"}}
+	assert.Equal(t, "missing required configuration: FOO, BAR", err.Error())
+}
+
+func TestParseError_Error(t *testing.T) {
+	t.Parallel()
+
+	exErr := errors.New("some error")
+	err := ParseError{Key: "FOO", Value: "bar", Err: exErr}
+	assert.Equal(t, `invalid value "bar" for FOO: some error`, err.Error())
+	assert.ErrorIs(t, err, exErr)
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// field is a settable leaf field of a configuration struct.
+type field struct {
+	path  []reflect.StructField
+	value reflect.Value
+}
+
+// Path returns the dotted Go path to the field, such as "DB.Host".
+func (f field) Path() string {
+	names := make([]string, len(f.path))
+	for i, sf := range f.path {
+		names[i] = sf.Name
+	}
+	return strings.Join(names, ".")
+}
+
+// Tag returns the value of the struct tag key on the leaf field.
+func (f field) Tag(key string) string {
+	return f.path[len(f.path)-1].Tag.Get(key)
+}
+
+// Required reports whether the field is tagged as required.
+func (f field) Required() bool {
+	required, _ := strconv.ParseBool(f.Tag("required"))
+	return required
+}
+
+// Key returns the field's name for the source using the struct tag key, joining
+// the names of nested structs with sep. Fields without a tag use the
+// transformed Go field name.
+func (f field) Key(tag, sep string, transform func(string) string) string {
+	names := make([]string, len(f.path))
+	for i, sf := range f.path {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "" {
+			name = transform(sf.Name)
+		}
+		names[i] = name
+	}
+	return strings.Join(names, sep)
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// fields returns the settable leaf fields of the struct pointed to by ptr,
+// recursing into nested structs. Fields tagged with `<skip>:"-"` are omitted.
+func fields(ptr any, skip string) ([]field, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: %T is not a pointer to a struct", ptr)
+	}
+
+	var out []field
+	var walk func(v reflect.Value, path []reflect.StructField)
+	walk = func(v reflect.Value, path []reflect.StructField) {
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() || sf.Tag.Get(skip) == "-" {
+				continue
+			}
+
+			fp := append(path[:len(path):len(path)], sf)
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Struct && !isLeaf(fv.Type()) {
+				walk(fv, fp)
+				continue
+			}
+			out = append(out, field{path: fp, value: fv})
+		}
+	}
+	walk(v.Elem(), nil)
+
+	return out, nil
+}
+
+func isLeaf(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// set parses s into v according to its type. Slices are parsed from a
+// comma-separated list of elements.
+func set(v reflect.Value, s string) error {
+	if v.CanAddr() {
+		if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return tu.UnmarshalText([]byte(s))
+		}
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := set(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+
+	return nil
+}
+
+// screamingSnake converts a Go identifier such as "MaxConns" or "HTTPAddr" to
+// "MAX_CONNS" or "HTTP_ADDR", respectively.
+func screamingSnake(name string) string {
+	runes := []rune(name)
+	builder := &strings.Builder{}
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Host string `env:"HOSTNAME"`
+		Port int
+	}
+
+	type cfg struct {
+		Name     string
+		DB       nested `env:"DATABASE"`
+		IP       net.IP
+		Ignored  string `env:"-"`
+		internal string
+	}
+
+	fs, err := fields(&cfg{}, "env")
+	require.NoError(t, err)
+
+	var paths, keys []string
+	for _, f := range fs {
+		paths = append(paths, f.Path())
+		keys = append(keys, f.Key("env", "_", screamingSnake))
+	}
+	assert.Equal(t, []string{"Name", "DB.Host", "DB.Port", "IP"}, paths)
+	assert.Equal(t, []string{"NAME", "DATABASE_HOSTNAME", "DATABASE_PORT", "IP"}, keys)
+
+	_, err = fields(cfg{}, "env")
+	assert.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in    string
+		ex    any
+		exErr bool
+	}{
+		{in: "foo", ex: "foo"},
+		{in: "true", ex: true},
+		{in: "nope", ex: false, exErr: true},
+		{in: "-12", ex: -12},
+		{in: "0x10", ex: int64(16)},
+		{in: "300", ex: int8(0), exErr: true},
+		{in: "12", ex: uint16(12)},
+		{in: "1.5", ex: 1.5},
+		{in: "1m30s", ex: 90 * time.Second},
+		{in: "a, b,c", ex: []string{"a", "b", "c"}},
+		{in: "1,2", ex: []int{1, 2}},
+		{in: "1,x", ex: []int(nil), exErr: true},
+		{in: "127.0.0.1", ex: net.ParseIP("127.0.0.1")},
+		{in: "x", ex: map[string]string(nil), exErr: true},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(reflect.TypeOf(tc.ex).String()+"/"+tc.in, func(t *testing.T) {
+			t.Parallel()
+
+			v := reflect.New(reflect.TypeOf(tc.ex)).Elem()
+			err := set(v, tc.in)
+			if tc.exErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ex, v.Interface())
+		})
+	}
+}
+
+func TestScreamingSnake(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Name":      "NAME",
+		"MaxConns":  "MAX_CONNS",
+		"HTTPAddr":  "HTTP_ADDR",
+		"UserID":    "USER_ID",
+		"V2Enabled": "V2_ENABLED",
+	}
+
+	for in, ex := range tests {
+		assert.Equal(t, ex, screamingSnake(in), in)
+	}
+}
+
+// assertSources asserts that every binding on c was recorded as bound from
+// file, rather than from within this package.
+func assertSources(t *testing.T, c *ioc.Container, file string) {
+	t.Helper()
+
+	bindings := c.Bindings()
+	require.NotEmpty(t, bindings)
+	for _, b := range bindings {
+		assert.True(t, strings.HasPrefix(filepath.Base(b.Source), file+":"), b.String())
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rodaine/ioc"
+	"github.com/rodaine/ioc/internal/callsite"
+)
+
+// ErrNotParsed is returned when resolving a value bound via BindFlags, or
+// applying a FlagLayer, before the associated flag.FlagSet has been parsed.
+var ErrNotParsed = errors.New("config: flag set has not been parsed")
+
+// fieldValue adapts a configuration field to the flag.Value interface.
+type fieldValue struct {
+	v reflect.Value
+}
+
+func (fv fieldValue) String() string {
+	if !fv.v.IsValid() {
+		return ""
+	}
+	if fv.v.Kind() == reflect.Slice {
+		parts := make([]string, fv.v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(fv.v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(fv.v.Interface())
+}
+
+func (fv fieldValue) Set(s string) error {
+	return set(fv.v, s)
+}
+
+func (fv fieldValue) IsBoolFlag() bool {
+	return fv.v.IsValid() && fv.v.Kind() == reflect.Bool
+}
+
+// registerFlags defines a flag on fs for each field of the struct pointed to
+// by ptr, returning the fields keyed by flag name.
+func registerFlags(fs *flag.FlagSet, ptr any, prefix string) map[string]field {
+	targets, err := fields(ptr, "flag")
+	if err != nil {
+		panic(err)
+	}
+
+	out := make(map[string]field, len(targets))
+	for _, f := range targets {
+		name := f.Key("flag", ".", kebab)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fs.Var(fieldValue{v: f.value}, name, f.Tag("usage"))
+		out[name] = f
+	}
+	return out
+}
+
+// RegisterFlags defines a flag on fs for each field of a new T, returning a
+// pointer to the T that is populated when fs is parsed. T must be a struct
+// type; nested structs are registered recursively. Flags are named by prefix,
+// the names of any enclosing struct fields, and the field's own name, joined
+// with periods. Names are taken from the `flag` struct tag, falling back to
+// the field name in kebab-case. The following struct tags are also supported:
+//
+//	flag:"-"         the field is ignored
+//	usage:"text"     the usage text for the flag
+//	default:"value"  the default value of the flag
+//
+// See LoadEnv for the supported field types. RegisterFlags panics if T is not
+// a struct type, if a default cannot be parsed, or if a flag is already
+// defined on fs.
+func RegisterFlags[T any](fs *flag.FlagSet, prefix string) *T {
+	out := new(T)
+	registerDefaults(fs, out, prefix)
+	return out
+}
+
+// registerDefaults behaves like registerFlags, but also applies the default
+// values of the fields.
+func registerDefaults(fs *flag.FlagSet, ptr any, prefix string) map[string]field {
+	registered := registerFlags(fs, ptr, prefix)
+	for name, f := range registered {
+		if value := f.Tag("default"); value != "" {
+			if err := set(f.value, value); err != nil {
+				panic(ParseError{Key: name, Value: value, Err: err})
+			}
+			fs.Lookup(name).DefValue = value
+		}
+	}
+	return registered
+}
+
+// BindFlags registers the fields of T onto fs via RegisterFlags and binds an
+// anonymous provider for T that returns the parsed values. Resolving T before
+// fs is parsed returns ErrNotParsed. Fields tagged with `required:"true"` that
+// have no default and are not set on the command line are reported via a
+// RequiredError.
+func BindFlags[T any](c *ioc.Container, fs *flag.FlagSet, prefix string) {
+	callsite.Helper()
+
+	cfg := new(T)
+	registered := registerDefaults(fs, cfg, prefix)
+
+	ioc.Bind(c, func(*ioc.Container) (T, error) {
+		if !fs.Parsed() {
+			return *cfg, ErrNotParsed
+		}
+
+		set := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+		var missing []string
+		for name, f := range registered {
+			if f.Required() && f.Tag("default") == "" && !set[name] {
+				missing = append(missing, "-"+name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return *cfg, RequiredError{Keys: missing}
+		}
+
+		return *cfg, nil
+	})
+}
+
+// FlagLayer creates a Layer named "flags" that registers the fields of T onto
+// fs, named as described by RegisterFlags, and applies the values of only the
+// flags set on the command line. Defaults are not registered; use
+// DefaultsLayer instead. Applying the Layer before fs is parsed returns
+// ErrNotParsed.
+func FlagLayer[T any](fs *flag.FlagSet, prefix string) Layer[T] {
+	scratch := new(T)
+	registered := registerFlags(fs, scratch, prefix)
+
+	return Layer[T]{
+		Name: "flags",
+		Apply: func(cfg *T) ([]string, error) {
+			return applyFlags(fs, registered, cfg)
+		},
+	}
+}
+
+// applyFlags sets the fields of cfg from the flags in registered that were set
+// on the command line, returning the paths of the fields it set. It returns
+// ErrNotParsed if fs has not yet been parsed.
+func applyFlags[T any](fs *flag.FlagSet, registered map[string]field, cfg *T) (paths []string, err error) {
+	if !fs.Parsed() {
+		return nil, ErrNotParsed
+	}
+
+	targets, err := fields(cfg, "flag")
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]field, len(targets))
+	for _, f := range targets {
+		byPath[f.Path()] = f
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if src, ok := registered[f.Name]; ok {
+			byPath[src.Path()].value.Set(src.value)
+			paths = append(paths, src.Path())
+		}
+	})
+
+	return paths, nil
+}
+
+// kebab converts a Go identifier such as "MaxConns" or "HTTPAddr" to
+// "max-conns" or "http-addr", respectively.
+func kebab(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' {
+			return '-'
+		}
+		return unicode.ToLower(r)
+	}, screamingSnake(name))
+}
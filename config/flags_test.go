@@ -0,0 +1,160 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flagConfig struct {
+	Addr     string        `usage:"listen address" default:":8080"`
+	Timeout  time.Duration `flag:"wait" default:"5s"`
+	Verbose  bool
+	Tags     []string
+	Internal string `flag:"-"`
+	DB       struct {
+		DSN      string `required:"true"`
+		MaxConns int
+	}
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestRegisterFlags(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	cfg := RegisterFlags[flagConfig](fs, "app")
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	assert.Equal(t, []string{
+		"app.addr",
+		"app.db.dsn",
+		"app.db.max-conns",
+		"app.tags",
+		"app.verbose",
+		"app.wait",
+	}, names)
+
+	addr := fs.Lookup("app.addr")
+	assert.Equal(t, "listen address", addr.Usage)
+	assert.Equal(t, ":8080", addr.DefValue)
+	assert.Equal(t, ":8080", cfg.Addr)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+
+	require.NoError(t, fs.Parse([]string{
+		"-app.verbose",
+		"-app.wait=1m",
+		"-app.tags=a,b",
+		"-app.db.max-conns", "12",
+	}))
+	assert.True(t, cfg.Verbose)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, 12, cfg.DB.MaxConns)
+	assert.Equal(t, "a,b", fs.Lookup("app.tags").Value.String())
+
+	assert.Error(t, newFlagSet().Parse([]string{"-unknown"}))
+	assert.Error(t, fs.Parse([]string{"-app.wait=forever"}))
+}
+
+func TestRegisterFlags_Panics(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { RegisterFlags[string](newFlagSet(), "") })
+
+	type invalid struct {
+		N int `default:"x"`
+	}
+	assert.Panics(t, func() { RegisterFlags[invalid](newFlagSet(), "") })
+
+	fs := newFlagSet()
+	RegisterFlags[flagConfig](fs, "")
+	assert.Panics(t, func() { RegisterFlags[flagConfig](fs, "") })
+}
+
+func TestBindFlags(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	c := new(ioc.Container)
+	BindFlags[flagConfig](c, fs, "")
+	assertSources(t, c, "flags_test.go")
+
+	_, err := ioc.TryResolve[flagConfig](c)
+	assert.ErrorIs(t, err, ErrNotParsed)
+
+	require.NoError(t, fs.Parse([]string{"-db.dsn", "postgres://"}))
+	cfg := ioc.Resolve[flagConfig](c)
+	assert.Equal(t, ":8080", cfg.Addr)
+	assert.Equal(t, "postgres://", cfg.DB.DSN)
+}
+
+func TestBindFlags_Required(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	c := new(ioc.Container)
+	BindFlags[flagConfig](c, fs, "")
+	require.NoError(t, fs.Parse(nil))
+
+	_, err := ioc.TryResolve[flagConfig](c)
+	reqErr := RequiredError{}
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, []string{"-db.dsn"}, reqErr.Keys)
+}
+
+func TestFlagLayer(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	layer := FlagLayer[flagConfig](fs, "")
+	assert.Empty(t, fs.Lookup("addr").DefValue, "should not register defaults")
+
+	_, _, err := LoadLayered(layer)
+	assert.ErrorIs(t, err, ErrNotParsed)
+
+	require.NoError(t, fs.Parse([]string{"-wait=1m", "-db.dsn=postgres://"}))
+
+	cfg, expl, err := LoadLayered(
+		DefaultsLayer[flagConfig](),
+		FileLayer[flagConfig](JSON, Reader(strings.NewReader(`{"Addr": ":9090", "Verbose": true}`))),
+		layer,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.Addr)
+	assert.True(t, cfg.Verbose)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, "postgres://", cfg.DB.DSN)
+	assert.Equal(t, Explanation{
+		"Addr":    "file",
+		"Verbose": "file",
+		"Timeout": "flags",
+		"DB.DSN":  "flags",
+	}, expl)
+}
+
+func TestKebab(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Name":     "name",
+		"MaxConns": "max-conns",
+		"HTTPAddr": "http-addr",
+	}
+
+	for in, ex := range tests {
+		assert.Equal(t, ex, kebab(in), in)
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rodaine/ioc"
+	"github.com/rodaine/ioc/internal/callsite"
+)
+
+// Layer is a named source of configuration values that is applied on top of
+// the values supplied by the layers before it.
+type Layer[T any] struct {
+	Name string
+	// Apply sets the fields of cfg for which the layer has a value, returning
+	// the dotted Go paths (eg, "DB.Host") of the fields it set. Other fields
+	// should not be modified.
+	Apply func(cfg *T) (paths []string, err error)
+}
+
+// DefaultsLayer creates a Layer named "defaults" that sets fields from their
+// `default` struct tags. See LoadEnv for the supported field types.
+func DefaultsLayer[T any]() Layer[T] {
+	return Layer[T]{
+		Name: "defaults",
+		Apply: func(cfg *T) ([]string, error) {
+			fs, err := fields(cfg, "default")
+			if err != nil {
+				return nil, err
+			}
+
+			var paths []string
+			for _, f := range fs {
+				value := f.Tag("default")
+				if value == "" {
+					continue
+				}
+				if err = set(f.value, value); err != nil {
+					return paths, ParseError{Key: f.Path(), Value: value, Err: err}
+				}
+				paths = append(paths, f.Path())
+			}
+			return paths, nil
+		},
+	}
+}
+
+// FileLayer creates a Layer named "file" that decodes the document from src
+// on top of the existing values. Only the fields present in the document are
+// modified.
+func FileLayer[T any](format Format, src Source) Layer[T] {
+	return Layer[T]{
+		Name: "file",
+		Apply: func(cfg *T) ([]string, error) {
+			data, err := src()
+			if err != nil {
+				return nil, err
+			}
+			if err = format.unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("decoding %v config: %w", format, err)
+			}
+
+			var doc any
+			if err = format.unmarshalDoc(data, &doc); err != nil {
+				return nil, fmt.Errorf("decoding %v config: %w", format, err)
+			}
+
+			fs, err := fields(cfg, format.name)
+			if err != nil {
+				return nil, err
+			}
+
+			var paths []string
+			for _, f := range fs {
+				if format.present(doc, f.path) {
+					paths = append(paths, f.Path())
+				}
+			}
+			return paths, nil
+		},
+	}
+}
+
+// EnvLayer creates a Layer named "env" that sets fields from environment
+// variables, named as described by LoadEnv. Only the fields with a non-empty
+// variable are modified.
+func EnvLayer[T any](prefix string) Layer[T] {
+	return Layer[T]{
+		Name: "env",
+		Apply: func(cfg *T) ([]string, error) {
+			return applyEnv(cfg, prefix, false)
+		},
+	}
+}
+
+// Explanation maps the dotted Go path of each configuration field (eg,
+// "DB.Host") to the name of the last Layer that set it, which supplied its
+// final value. Fields not set by any Layer are omitted.
+type Explanation map[string]string
+
+func (e Explanation) String() string {
+	paths := make([]string, 0, len(e))
+	for path := range e {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	builder := &strings.Builder{}
+	for _, path := range paths {
+		_, _ = fmt.Fprintf(builder, "%s: %s\n", path, e[path])
+	}
+	return builder.String()
+}
+
+// LoadLayered merges the layers, in order, into a T. Fields tagged with
+// `required:"true"` that remain zero after all layers are applied are
+// reported via a RequiredError. The returned Explanation reports which Layer
+// supplied each field.
+func LoadLayered[T any](layers ...Layer[T]) (out T, expl Explanation, err error) {
+	fs, err := fields(&out, "")
+	if err != nil {
+		return out, nil, err
+	}
+
+	expl = Explanation{}
+	for _, layer := range layers {
+		var paths []string
+		if paths, err = layer.Apply(&out); err != nil {
+			return out, nil, fmt.Errorf("applying %s config layer: %w", layer.Name, err)
+		}
+		for _, path := range paths {
+			expl[path] = layer.Name
+		}
+	}
+
+	var missing []string
+	for _, f := range fs {
+		if f.Required() && f.value.IsZero() {
+			missing = append(missing, f.Path())
+		}
+	}
+	if len(missing) > 0 {
+		return out, expl, RequiredError{Keys: missing}
+	}
+
+	return out, expl, nil
+}
+
+type layerStack[T any] []Layer[T]
+
+type layered[T any] struct {
+	value T
+	expl  Explanation
+}
+
+// bindLayers binds the layers along with the providers for T and its
+// Explanation. If rebind is true, any existing bindings on c are replaced, as
+// AddLayers intends; otherwise, they are bound as if by ioc.BindNamed.
+func bindLayers[T any](c *ioc.Container, layers layerStack[T], rebind bool) {
+	callsite.Helper()
+
+	bindStack, bindLayered, bindValue := ioc.BindNamed[layerStack[T]], ioc.BindNamed[layered[T]], ioc.BindNamed[T]
+	if rebind {
+		bindStack, bindLayered, bindValue = ioc.RebindNamed[layerStack[T]], ioc.RebindNamed[layered[T]], ioc.RebindNamed[T]
+	}
+
+	// bind T first, so that a conflict in strict mode leaves no other bindings
+	bindValue(c, "", func(c *ioc.Container) (T, error) {
+		l, err := ioc.TryResolve[layered[T]](c)
+		return l.value, err
+	})
+	bindStack(c, "", ioc.Static(layers))
+	bindLayered(c, "", ioc.Singleton(func(c *ioc.Container) (layered[T], error) {
+		value, expl, err := LoadLayered[T](layers...)
+		return layered[T]{value: value, expl: expl}, err
+	}))
+}
+
+// BindLayered binds an anonymous provider for T that merges the layers via
+// LoadLayered the first time it is resolved. Typical usage orders the layers
+// by increasing precedence:
+//
+//	config.BindLayered(c,
+//		config.DefaultsLayer[AppConfig](),
+//		config.FileLayer[AppConfig](config.YAML, config.File("config.yaml")),
+//		config.EnvLayer[AppConfig]("APP"),
+//	)
+//
+// Use Explain to report which layer supplied each field. BindLayered binds T
+// like ioc.Bind, so in strict mode it panics with an ioc.DuplicateBindingError
+// if T is already bound to c.
+func BindLayered[T any](c *ioc.Container, layers ...Layer[T]) {
+	callsite.Helper()
+	bindLayers[T](c, layers, false)
+}
+
+// AddLayers binds an anonymous provider for T to c that applies the layers on
+// top of those bound via BindLayered (or AddLayers) on c or its ancestors in
+// the Extend chain. The parent's provider is left unmodified, so an Extend'd
+// Container can add a layer without affecting its parent. The existing layers
+// are captured when AddLayers is called. Unlike BindLayered, AddLayers
+// replaces any existing provider for T on c, even in strict mode.
+func AddLayers[T any](c *ioc.Container, layers ...Layer[T]) {
+	callsite.Helper()
+
+	existing, _, err := ioc.TryResolveOptional[layerStack[T]](c)
+	if err != nil {
+		panic(err)
+	}
+
+	stack := make(layerStack[T], 0, len(existing)+len(layers))
+	stack = append(stack, existing...)
+	stack = append(stack, layers...)
+
+	bindLayers[T](c, stack, true)
+}
+
+// Explain returns the Explanation for the T bound via BindLayered or
+// AddLayers, loading it if it has not yet been resolved.
+func Explain[T any](c *ioc.Container) (Explanation, error) {
+	l, err := ioc.TryResolve[layered[T]](c)
+	return l.expl, err
+}
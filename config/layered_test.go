@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type layeredConfig struct {
+	Addr    string        `json:"addr" default:":8080"`
+	Timeout time.Duration `json:"timeout" default:"5s"`
+	Labels  map[string]string
+	DB      struct {
+		DSN string `json:"dsn" required:"true"`
+	} `json:"db"`
+}
+
+func TestLoadLayered(t *testing.T) {
+	t.Setenv("LAYERED_TIMEOUT", "1m")
+
+	cfg, expl, err := LoadLayered(
+		DefaultsLayer[layeredConfig](),
+		FileLayer[layeredConfig](JSON, Reader(strings.NewReader(
+			`{"addr": ":9090", "db": {"dsn": "postgres://"}, "Labels": {"a": "b"}}`))),
+		EnvLayer[layeredConfig]("LAYERED"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, ":9090", cfg.Addr)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, "postgres://", cfg.DB.DSN)
+	assert.Equal(t, map[string]string{"a": "b"}, cfg.Labels)
+
+	assert.Equal(t, Explanation{
+		"Addr":    "file",
+		"Timeout": "env",
+		"DB.DSN":  "file",
+		"Labels":  "file",
+	}, expl)
+	assert.Equal(t, "Addr: file\nDB.DSN: file\nLabels: file\nTimeout: env\n", expl.String())
+}
+
+func TestLoadLayered_SameValue(t *testing.T) {
+	t.Parallel()
+
+	_, expl, err := LoadLayered(
+		DefaultsLayer[layeredConfig](),
+		FileLayer[layeredConfig](JSON, Reader(strings.NewReader(`{"addr": ":8080", "DB": {"DSN": "x"}}`))),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Explanation{
+		"Addr":    "file",
+		"Timeout": "defaults",
+		"DB.DSN":  "file",
+	}, expl, "should credit the layer that set the value, even if unchanged")
+}
+
+func TestFileLayer_Fields(t *testing.T) {
+	t.Parallel()
+
+	type Embedded struct {
+		Region string `yaml:"region"`
+	}
+	type fileConfig struct {
+		Embedded `yaml:",inline"`
+		Name     string
+		Port     int    `yaml:"port" json:"port"`
+		Skipped  string `yaml:"-" json:"-"`
+		Nested   struct {
+			Host string `yaml:"host" json:"host"`
+		} `yaml:"nested" json:"nested"`
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		doc    string
+		paths  []string
+	}{
+		{
+			name:   "json",
+			format: JSON,
+			doc:    `{"name": "x", "PORT": 1, "nested": {"host": "h"}, "Region": "r", "skipped": "s"}`,
+			paths:  []string{"Embedded.Region", "Name", "Port", "Nested.Host"},
+		},
+		{
+			name:   "json missing",
+			format: JSON,
+			doc:    `{"nested": {}}`,
+		},
+		{
+			name:   "yaml",
+			format: YAML,
+			doc:    "name: x\nport: 1\nnested:\n  host: h\nregion: r\nskipped: s\n",
+			paths:  []string{"Embedded.Region", "Name", "Port", "Nested.Host"},
+		},
+		{
+			name:   "yaml case sensitive",
+			format: YAML,
+			doc:    "Name: x\nPort: 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := fileConfig{}
+			paths, err := FileLayer[fileConfig](tc.format, Reader(strings.NewReader(tc.doc))).Apply(&cfg)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.paths, paths)
+		})
+	}
+}
+
+func TestLoadLayered_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, expl, err := LoadLayered(DefaultsLayer[layeredConfig]())
+	reqErr := RequiredError{}
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, []string{"DB.DSN"}, reqErr.Keys)
+	assert.Equal(t, Explanation{"Addr": "defaults", "Timeout": "defaults"}, expl)
+
+	exErr := errors.New("some error")
+	_, _, err = LoadLayered(Layer[layeredConfig]{
+		Name:  "broken",
+		Apply: func(*layeredConfig) ([]string, error) { return nil, exErr },
+	})
+	assert.ErrorIs(t, err, exErr)
+	assert.ErrorContains(t, err, "applying broken config layer")
+
+	_, _, err = LoadLayered(FileLayer[layeredConfig](JSON, Reader(strings.NewReader("{"))))
+	assert.ErrorContains(t, err, "decoding json config")
+
+	type invalid struct {
+		N int `default:"x"`
+	}
+	_, _, err = LoadLayered(DefaultsLayer[invalid]())
+	assert.ErrorAs(t, err, &ParseError{})
+
+	_, _, err = LoadLayered[string]()
+	assert.Error(t, err)
+}
+
+func TestBindLayered(t *testing.T) {
+	t.Parallel()
+
+	dsn := func(v string) Layer[layeredConfig] {
+		return Layer[layeredConfig]{
+			Name: "dsn:" + v,
+			Apply: func(cfg *layeredConfig) ([]string, error) {
+				cfg.DB.DSN = v
+				return []string{"DB.DSN"}, nil
+			},
+		}
+	}
+
+	parent := new(ioc.Container)
+	BindLayered(parent, DefaultsLayer[layeredConfig](), dsn("postgres://"))
+	parent.Freeze()
+
+	child := parent.Extend()
+	AddLayers(child, dsn("mysql://"))
+	assertSources(t, child, "layered_test.go")
+
+	assert.Equal(t, "postgres://", ioc.Resolve[layeredConfig](parent).DB.DSN)
+	assert.Equal(t, "mysql://", ioc.Resolve[layeredConfig](child).DB.DSN)
+	assert.Equal(t, ":8080", ioc.Resolve[layeredConfig](child).Addr)
+
+	expl, err := Explain[layeredConfig](parent)
+	require.NoError(t, err)
+	assert.Equal(t, "dsn:postgres://", expl["DB.DSN"])
+
+	expl, err = Explain[layeredConfig](child)
+	require.NoError(t, err)
+	assert.Equal(t, "dsn:mysql://", expl["DB.DSN"])
+	assert.Equal(t, "defaults", expl["Addr"])
+}
+
+func TestBindLayered_Strict(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	c.Strict()
+	ioc.Bind(c, ioc.Static(layeredConfig{Addr: "mine"}))
+
+	assert.Panics(t, func() { BindLayered(c, DefaultsLayer[layeredConfig]()) })
+	assert.Equal(t, "mine", ioc.Resolve[layeredConfig](c).Addr)
+	assert.Len(t, c.Bindings(), 1)
+
+	lenient := new(ioc.Container)
+	BindLayered(lenient, DefaultsLayer[layeredConfig]())
+	assert.NotPanics(t, func() { AddLayers(lenient, DefaultsLayer[layeredConfig]()) })
+}
+
+func TestAddLayers(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	c.Strict()
+	AddLayers(c, DefaultsLayer[layeredConfig]())
+
+	_, err := ioc.TryResolve[layeredConfig](c)
+	assert.ErrorAs(t, err, &RequiredError{})
+
+	_, err = Explain[layeredConfig](c)
+	assert.ErrorAs(t, err, &RequiredError{})
+}
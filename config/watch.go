@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/rodaine/ioc/internal/callsite"
+)
+
+// WatchOptions configures WatchFile.
+type WatchOptions struct {
+	// Interval is how often the file is polled for changes. If zero, the file
+	// is polled every second.
+	Interval time.Duration
+	// Section is the dotted path of the section of the document to decode, as
+	// described by LoadSection. If empty, the whole document is decoded.
+	Section string
+	// OnError, if non-nil, is called with any error reading, decoding, or
+	// validating the file. The last good value is retained.
+	OnError func(error)
+}
+
+// WatchFile polls the file at path for changes, decoding it into a T and
+// storing it in r each time its contents change. Errors do not stop the
+// watcher, and r keeps its last good value. Errors decoding or validating the
+// file are reported via opts.OnError once per change to the file; errors
+// reading it (eg, while it is missing) are reported once until the error
+// changes or the file is read successfully. WatchFile blocks until ctx is
+// done, so it is typically run in its own goroutine:
+//
+//	r := ioc.NewReloadable(initial, validate)
+//	ioc.BindReloadable(c, r)
+//	go config.WatchFile(ctx, r, config.YAML, "config.yaml", config.WatchOptions{})
+//
+// Only the standard library is used to detect changes: the file's contents are
+// compared to those last decoded on each poll. The file is checked once
+// immediately.
+func WatchFile[T any](ctx context.Context, r *ioc.Reloadable[T], format Format, path string, opts WatchOptions) {
+	watchFile(ctx, r, format, path, opts, nil)
+}
+
+// watchFile implements WatchFile. If last is non-nil, it is treated as the
+// contents of the file that produced the current value of r.
+func watchFile[T any](ctx context.Context, r *ioc.Reloadable[T], format Format, path string, opts WatchOptions, last []byte) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	report := func(err error) {
+		if opts.OnError != nil {
+			opts.OnError(err)
+		}
+	}
+
+	src := File(path)
+	var readErr string
+	poll := func() {
+		data, err := src()
+		if err != nil {
+			if err.Error() != readErr {
+				readErr = err.Error()
+				report(err)
+			}
+			return
+		}
+		readErr = ""
+		if last != nil && bytes.Equal(data, last) {
+			return
+		}
+		last = data
+
+		value, err := LoadSection[T](format, static(data), opts.Section)
+		if err == nil {
+			err = r.Store(value)
+		}
+		if err != nil {
+			report(err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for poll(); ; {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+// static creates a Source that always returns data.
+func static(data []byte) Source {
+	return func() ([]byte, error) { return data, nil }
+}
+
+// BindWatchedFile loads the file at path into a T, binds it via
+// ioc.BindReloadable, and starts a goroutine that keeps it up to date via
+// WatchFile until ctx is done. An error is returned if the file cannot be
+// loaded or is invalid initially. validate may be nil; see ioc.NewReloadable.
+func BindWatchedFile[T any](ctx context.Context, c *ioc.Container, format Format, path string, validate func(T) error, opts WatchOptions) (*ioc.Reloadable[T], error) {
+	callsite.Helper()
+
+	data, err := File(path)()
+	if err != nil {
+		return nil, err
+	}
+
+	initial, err := LoadSection[T](format, static(data), opts.Section)
+	if err != nil {
+		return nil, err
+	}
+	if validate != nil {
+		if err = validate(initial); err != nil {
+			return nil, err
+		}
+	}
+
+	r := ioc.NewReloadable(initial, validate)
+	ioc.BindReloadable(c, r)
+	go watchFile(ctx, r, format, path, opts, data)
+
+	return r, nil
+}
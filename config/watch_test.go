@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchConfig struct {
+	Level string `json:"level"`
+}
+
+// writeFile atomically replaces the contents of path so that the watcher never
+// observes a partially written file.
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(contents), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+// receive returns the next value from ch, failing the test if none is sent
+// before the deadline.
+func receive[T any](t *testing.T, ch chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a value")
+		return *new(T)
+	}
+}
+
+// assertNoReceive asserts that no value is sent on ch within a few polling
+// intervals of a watcher.
+func assertNoReceive[T any](t *testing.T, ch chan T, msg string) {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		assert.Failf(t, "unexpected value", "%s: got %v", msg, v)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"level": "info"}`)
+
+	errs := make(chan error, 10)
+	values := make(chan string, 10)
+
+	r := ioc.NewReloadable(watchConfig{}, func(cfg watchConfig) error {
+		if cfg.Level == "" {
+			return errors.New("level is required")
+		}
+		return nil
+	})
+	r.Subscribe(func(cfg watchConfig) { values <- cfg.Level })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WatchFile(ctx, r, JSON, path, WatchOptions{
+			Interval: time.Millisecond,
+			OnError:  func(err error) { errs <- err },
+		})
+	}()
+
+	assert.Equal(t, "info", receive(t, values), "should load immediately")
+
+	writeFile(t, path, `{"level": ""}`)
+	assert.ErrorContains(t, receive(t, errs), "level is required")
+
+	writeFile(t, path, `{`)
+	assert.ErrorContains(t, receive(t, errs), "decoding json config")
+	assert.Equal(t, "info", r.Load().Level, "should retain the last good value")
+
+	writeFile(t, path, `{"level": "debug"}`)
+	assert.Equal(t, "debug", receive(t, values))
+
+	cancel()
+	receive(t, done)
+	assert.Empty(t, errs, "should report each error once")
+}
+
+func TestWatchFile_Missing(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	r := ioc.NewReloadable(watchConfig{Level: "info"}, nil)
+	values := make(chan string, 10)
+	r.Subscribe(func(cfg watchConfig) { values <- cfg.Level })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	errs := make(chan error, 10)
+	go func() {
+		defer close(done)
+		WatchFile(ctx, r, JSON, path, WatchOptions{
+			Interval: time.Millisecond,
+			OnError:  func(err error) { errs <- err },
+		})
+	}()
+
+	assert.ErrorIs(t, receive(t, errs), os.ErrNotExist)
+	assert.Equal(t, "info", r.Load().Level)
+	assertNoReceive(t, errs, "should not report the same read error on every poll")
+
+	writeFile(t, path, `{"level": "debug"}`)
+	assert.Equal(t, "debug", receive(t, values))
+
+	require.NoError(t, os.Remove(path))
+	assert.ErrorIs(t, receive(t, errs), os.ErrNotExist, "should report again after a successful read")
+
+	cancel()
+	receive(t, done)
+	assert.Empty(t, errs)
+}
+
+func TestBindWatchedFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	writeFile(t, path, `{"app": {"level": "info"}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := new(ioc.Container)
+	r, err := BindWatchedFile[watchConfig](ctx, c, JSON, path, nil, WatchOptions{
+		Interval: time.Millisecond,
+		Section:  "app",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "info", ioc.Resolve[watchConfig](c).Level)
+	assertSources(t, c, "watch_test.go")
+
+	values := make(chan string, 10)
+	r.Subscribe(func(cfg watchConfig) { values <- cfg.Level })
+
+	writeFile(t, path, `{"app": {"level": "debug"}}`)
+	assert.Equal(t, "debug", receive(t, values))
+	assert.Equal(t, "debug", ioc.Resolve[watchConfig](c).Level)
+	assert.Same(t, r, ioc.Resolve[*ioc.Reloadable[watchConfig]](c))
+}
+
+func TestBindWatchedFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	_, err := BindWatchedFile[watchConfig](ctx, new(ioc.Container), JSON,
+		filepath.Join(dir, "missing.json"), nil, WatchOptions{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(dir, "config.json")
+	writeFile(t, path, `{"level": ""}`)
+
+	exErr := errors.New("invalid")
+	_, err = BindWatchedFile[watchConfig](ctx, new(ioc.Container), JSON, path,
+		func(watchConfig) error { return exErr }, WatchOptions{})
+	assert.ErrorIs(t, err, exErr)
+
+	_, err = BindWatchedFile[watchConfig](ctx, new(ioc.Container), JSON, path,
+		nil, WatchOptions{Section: "app"})
+	assert.ErrorAs(t, err, &RequiredError{})
+}
@@ -2,7 +2,13 @@ package ioc
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
 	"sync/atomic"
+
+	"github.com/rodaine/ioc/internal/callsite"
 )
 
 type noCopy struct{}
@@ -12,26 +18,122 @@ func (*noCopy) Unlock() {}
 
 const anonymous = ""
 
+type option uint32
+
+const (
+	optStrict option = 1 << iota
+	optRepanic
+	optAssignable
+)
+
+// registration is a ProviderFunc bound to a Container, along with the call
+// site, Module, and Condition (if any) that bound it and the kind of provider.
+type registration struct {
+	provider  providerFunc
+	owner     *Container
+	source    string
+	module    string
+	condition string
+	kind      string
+	deps      *dependencySet
+}
+
+// callerSource returns the file:line of the caller skip frames above the
+// function calling callerSource, skipping frames marked via callsite.Helper.
+func callerSource(skip int) string {
+	return callsite.Source(skip + 1)
+}
+
 // Container is an inversion-of-control container. Providers for type
 // constructors can be bound to the container using Bind and BindNamed. Once
 // all providers have been attached, Freeze can be called to obtain a Resolver.
 // The Container and associated functions are thread-safe.
 type Container struct {
-	_         noCopy
-	parent    *Container
-	providers syncMap[typeName, providerFunc]
-	frozen    atomic.Bool
-	resolving typeName
-	ctx       context.Context
+	_            noCopy
+	parent       *Container
+	providers    syncMap[typeName, registration]
+	defaults     syncMap[typeName, registration]
+	frozen       atomic.Bool
+	freezeOnce   sync.Once
+	options      atomic.Uint32
+	modules      syncMap[string, *Module]
+	installMu    sync.Mutex
+	module       *Module
+	profiles     syncMap[string, struct{}]
+	condMu       sync.Mutex
+	conditionals []*conditional
+	closeMu      sync.Mutex
+	closers      []func() error
+	closed       bool
+	resolving    typeName
+	source       string
+	owner        *Container
+	deps         *dependencySet
+	graphing     bool
+	ctx          context.Context
 }
 
 // Freeze prevents any more providers from being bound to the Container and
 // returns a Resolver that can be used with the TryResolve* and Resolve*
 // functions to produce values from the container. Calls to Bind or BindNamed
-// after Freeze has been called will result in a panic. Freeze is idempotent
-// and can be called multiple times safely.
+// after Freeze has been called will result in a panic. Freeze also evaluates
+// the Condition of any bindings made via BindIf or BindNamedIf, activating
+// those that are met. Freeze is idempotent and can be called multiple times
+// safely.
 func (c *Container) Freeze() {
-	c.frozen.Store(true)
+	c = c.target()
+	c.freezeOnce.Do(func() {
+		defer c.frozen.Store(true)
+		c.activate()
+	})
+}
+
+// Strict enables strict mode on the Container and any Containers extending it.
+// In strict mode, binding a provider for a type and name that is already bound
+// to the same Container results in a panic with a DuplicateBindingError.
+// RebindNamed and UnbindNamed can be used where replacing a binding is
+// intentional. Overriding a parent's binding from an extending Container is
+// always permitted.
+func (c *Container) Strict() {
+	c.enable(optStrict)
+}
+
+// Repanic disables the recovery of panics within providers on the Container
+// and any Containers extending it. By default, a panicking ProviderFunc is
+// converted into a ProviderPanicError returned by the TryResolve* functions.
+// With Repanic enabled, the panic propagates unaltered, preserving the original
+// stack for debugging.
+func (c *Container) Repanic() {
+	c.enable(optRepanic)
+}
+
+// MatchAssignable enables assignability-based resolution on the Container and
+// any Containers extending it. When an interface type is requested and no
+// provider is bound for it exactly, the bindings with the same name whose
+// concrete types implement the interface are considered instead. If exactly
+// one such binding exists, it is used to resolve the interface; if more than
+// one does, an AmbiguousProviderError is returned.
+func (c *Container) MatchAssignable() {
+	c.enable(optAssignable)
+}
+
+func (c *Container) enable(opt option) {
+	c = c.target()
+	for {
+		old := c.options.Load()
+		if c.options.CompareAndSwap(old, old|uint32(opt)) {
+			return
+		}
+	}
+}
+
+func (c *Container) enabled(opt option) bool {
+	for rc := c; rc != nil; rc = rc.parent {
+		if option(rc.options.Load())&opt != 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // Extend creates a new Container with the current container as its parent. This
@@ -43,7 +145,7 @@ func (c *Container) Freeze() {
 // then extended with more domain-specific bindings to scope access.
 func (c *Container) Extend() *Container {
 	return &Container{
-		parent: c,
+		parent: c.target(),
 		ctx:    c.ctx,
 	}
 }
@@ -58,7 +160,25 @@ func (c *Container) Context() context.Context {
 	return context.Background()
 }
 
-func (c *Container) startResolving(ctx context.Context, name typeName) (*Container, error) {
+// Chain returns the types and names (formatted like "*sql.DB:primary") in the
+// process of being resolved, starting with the outermost. Within a
+// ProviderFunc, the last element is the value being provided. Chain is empty
+// outside of a ProviderFunc.
+func (c *Container) Chain() []string {
+	var chain []string
+	for _, tn := range c.resolvingChain(typeName{}) {
+		if tn != (typeName{}) {
+			chain = append(chain, tn.String())
+		}
+	}
+	return chain
+}
+
+// startResolving creates the Container passed to the provider of reg when
+// resolving name. The dependencies the provider resolves are recorded to reg
+// only if graphing is true or the provider is a Singleton, whose dependencies
+// are otherwise unobservable once it is initialized.
+func (c *Container) startResolving(ctx context.Context, name typeName, reg registration, graphing bool) (*Container, error) {
 	for rc := c; rc != nil; rc = rc.parent {
 		if rc.resolving == name {
 			return nil, CircularDependencyError(c.resolvingChain(name))
@@ -69,12 +189,45 @@ func (c *Container) startResolving(ctx context.Context, name typeName) (*Contain
 		parent:    c,
 		ctx:       ctx,
 		resolving: name,
+		source:    reg.source,
+		graphing:  graphing,
+	}
+	if reg.kind == "singleton" {
+		// the value outlives the resolution, so its hooks go to its owner
+		resolver.owner = reg.owner
+	}
+	if graphing || reg.kind == "singleton" {
+		resolver.deps = reg.deps
 	}
 	resolver.Freeze()
 
 	return resolver, nil
 }
 
+// provide calls the provider for name with the resolver, recovering any panic
+// into a ProviderPanicError unless Repanic is enabled. Panics from Resolve*
+// functions within the provider that already carry a resolving chain are
+// returned as-is.
+func (c *Container) provide(resolver *Container, name typeName, provider providerFunc) (v any, err error) {
+	if !c.enabled(optRepanic) {
+		defer func() {
+			if r := recover(); r != nil {
+				if e, ok := r.(error); ok && hasChain(e) {
+					err = e
+					return
+				}
+				err = ProviderPanicError{
+					chain: c.resolvingChain(name),
+					Value: r,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+	}
+
+	return provider(resolver)
+}
+
 func (c *Container) resolvingChain(tn typeName) []typeName {
 	chain := []typeName{tn}
 	for rc := c; rc != nil; rc = rc.parent {
@@ -88,27 +241,196 @@ func (c *Container) resolvingChain(tn typeName) []typeName {
 	return chain
 }
 
-func (c *Container) lookup(name typeName) (providerFunc, error) {
+// unwind returns the nearest Container in the chain that is not an in-flight
+// resolution of a provider. Values captured for later resolution (eg, Lazy)
+// use it so that they do not inherit a resolving chain that has since ended.
+func (c *Container) unwind() *Container {
+	for c.resolving != (typeName{}) && c.parent != nil {
+		c = c.parent
+	}
+	return c
+}
+
+func (c *Container) lookup(name typeName) (registration, error) {
+	for rc := c; rc != nil; rc = rc.parent {
+		if reg, ok := rc.providers.Load(name); ok {
+			return reg, nil
+		}
+	}
 	for rc := c; rc != nil; rc = rc.parent {
-		if provider, ok := rc.providers.Load(name); ok {
-			return provider, nil
+		if reg, ok := rc.defaults.Load(name); ok {
+			return reg, nil
 		}
 	}
-	return nil, MissingProviderError(name)
+	if name.Type.Kind() == reflect.Interface && c.enabled(optAssignable) {
+		return c.lookupAssignable(name)
+	}
+	return registration{}, MissingProviderError{name: name}
 }
 
-// BindNamed associates a ProviderFunc with the specified name and type. Note
-// that type aliases (type Foo = Bar) are treated as the same type.
-func BindNamed[T any](c *Container, name string, fn ProviderFunc[T]) {
+// lookupAssignable finds the unique binding with the same name as the interface
+// type in name whose concrete type implements it.
+func (c *Container) lookupAssignable(name typeName) (registration, error) {
+	var candidates []Binding
+	for _, b := range c.Bindings() {
+		tn := b.typeName()
+		if b.Shadowed || tn.Name != name.Name || tn.Qualifier != name.Qualifier {
+			continue
+		}
+		if b.Type.Kind() != reflect.Interface && b.Type.Implements(name.Type) {
+			candidates = append(candidates, b)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return registration{}, MissingProviderError{name: name}
+	case 1:
+		return c.lookup(candidates[0].typeName())
+	default:
+		return registration{}, AmbiguousProviderError{
+			name:       name,
+			Candidates: candidates,
+		}
+	}
+}
+
+// missingProvider creates a MissingProviderError for name, including hints
+// about the provider requiring it, if any, and near matches among the bound
+// providers. Since computing the hints inspects every binding, lookup returns
+// a MissingProviderError without hints, which is replaced via missingProvider
+// only when it is returned to the caller.
+func (c *Container) missingProvider(name typeName) MissingProviderError {
+	err := MissingProviderError{name: name}
+	if c.resolving != (typeName{}) {
+		err.Hints = append(err.Hints, fmt.Sprintf("required by %v (bound at %s)", c.resolving, c.source))
+	}
+	err.Hints = append(err.Hints, c.suggestions(name)...)
+	return err
+}
+
+func (c *Container) checkFrozen() {
 	if c.frozen.Load() {
 		panic("ioc.Container is frozen; no new providers may be bound")
 	}
+}
+
+// bind adds reg as the provider for name to the Container targeted by c, or as
+// the default provider if asDefault is true.
+func (c *Container) bind(name typeName, reg registration, asDefault bool) {
+	t := c.target()
+	t.checkFrozen()
+	c.attribute(&reg)
+	reg.owner = t
+	reg.deps = &dependencySet{}
 
-	c.providers.Store(newTypeName[T](name), fn.provide)
+	providers := &t.providers
+	if asDefault {
+		providers = &t.defaults
+	}
+
+	if !t.enabled(optStrict) {
+		providers.Store(name, reg)
+		return
+	}
+
+	if existing, loaded := providers.LoadOrStore(name, reg); loaded {
+		panic(DuplicateBindingError{
+			name:      name,
+			Existing:  existing.source,
+			Duplicate: reg.source,
+		})
+	}
+}
+
+func (c *Container) rebind(name typeName, reg registration) {
+	t := c.target()
+	t.checkFrozen()
+	c.attribute(&reg)
+	reg.owner = t
+	reg.deps = &dependencySet{}
+	t.providers.Store(name, reg)
+}
+
+// newRegistration creates the registration for fn, recording the call site
+// skip frames above the caller of the function calling newRegistration.
+func newRegistration[T any](fn ProviderFunc[T], skip int) registration {
+	return registration{
+		provider: fn.provide,
+		source:   callerSource(skip + 1),
+		kind:     providerKind(fn),
+	}
+}
+
+func bindNamed[T any](c *Container, name string, fn ProviderFunc[T], skip int) {
+	c.bind(newTypeName[T](name), newRegistration(fn, skip+1), false)
+}
+
+func bindDefaultNamed[T any](c *Container, name string, fn ProviderFunc[T], skip int) {
+	c.bind(newTypeName[T](name), newRegistration(fn, skip+1), true)
+}
+
+func rebindNamed[T any](c *Container, name string, fn ProviderFunc[T], skip int) {
+	c.rebind(newTypeName[T](name), newRegistration(fn, skip+1))
+}
+
+// BindNamed associates a ProviderFunc with the specified name and type. Note
+// that type aliases (type Foo = Bar) are treated as the same type.
+func BindNamed[T any](c *Container, name string, fn ProviderFunc[T]) {
+	bindNamed(c, name, fn, 0)
 }
 
 // Bind associates a ProviderFunc with the specified type anonymously. It is
 // equivalent to calling BindNamed with an empty name argument.
 func Bind[T any](c *Container, fn ProviderFunc[T]) {
-	BindNamed[T](c, anonymous, fn)
+	bindNamed(c, anonymous, fn, 0)
+}
+
+// BindDefaultNamed associates a fallback ProviderFunc with the specified name
+// and type. A default is only used if no Container in the Extend chain
+// (including children of this Container) binds the same type and name via
+// BindNamed, regardless of the order the bindings are made. This allows
+// library packages to provide sensible defaults that applications may
+// override.
+func BindDefaultNamed[T any](c *Container, name string, fn ProviderFunc[T]) {
+	bindDefaultNamed(c, name, fn, 0)
+}
+
+// BindDefault associates a fallback ProviderFunc with the specified type
+// anonymously. It is equivalent to calling BindDefaultNamed with an empty name
+// argument.
+func BindDefault[T any](c *Container, fn ProviderFunc[T]) {
+	bindDefaultNamed(c, anonymous, fn, 0)
+}
+
+// RebindNamed behaves like BindNamed, but replaces any existing provider for
+// the specified name and type on the Container, even in strict mode. Calls to
+// RebindNamed after Freeze has been called will result in a panic.
+func RebindNamed[T any](c *Container, name string, fn ProviderFunc[T]) {
+	rebindNamed(c, name, fn, 0)
+}
+
+// Rebind behaves like Bind, but replaces any existing anonymous provider for
+// the specified type on the Container, even in strict mode. It is equivalent
+// to calling RebindNamed with an empty name argument.
+func Rebind[T any](c *Container, fn ProviderFunc[T]) {
+	rebindNamed(c, anonymous, fn, 0)
+}
+
+// UnbindNamed removes the provider bound via BindNamed for the specified name
+// and type from the Container, reporting whether one was removed. Bindings on
+// parent Containers and defaults are unaffected. Calls to UnbindNamed after
+// Freeze has been called will result in a panic.
+func UnbindNamed[T any](c *Container, name string) bool {
+	c = c.target()
+	c.checkFrozen()
+	_, removed := c.providers.LoadAndDelete(newTypeName[T](name))
+	return removed
+}
+
+// Unbind removes the anonymous provider for the specified type from the
+// Container, reporting whether one was removed. It is equivalent to calling
+// UnbindNamed with an empty name argument.
+func Unbind[T any](c *Container) bool {
+	return UnbindNamed[T](c, anonymous)
 }
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type someStruct struct {
@@ -145,6 +147,25 @@ func TestContainer_Context(t *testing.T) {
 	assert.Equal(t, context.Background(), c.Context())
 }
 
+func TestContainer_Chain(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	assert.Empty(t, c.Chain())
+
+	var chain []string
+	BindNamed(c, "foo", Infallible(func(c *Container) string {
+		chain = c.Chain()
+		return "bar"
+	}))
+	Bind(c, Infallible(func(c *Container) int {
+		return len(ResolveNamed[string](c, "foo"))
+	}))
+
+	Resolve[int](c.Extend())
+	assert.Equal(t, []string{"int", "string:foo"}, chain)
+}
+
 func TestContainer_Extend(t *testing.T) {
 	t.Parallel()
 
@@ -158,3 +179,236 @@ func TestContainer_Extend(t *testing.T) {
 	assert.Equal(t, 123, Resolve[int](ext))
 	assert.Equal(t, "foo", Resolve[string](ext))
 }
+
+func TestBindDefault(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindDefault(c, Static(123))
+	assert.Equal(t, 123, Resolve[int](c))
+
+	Bind(c, Static(456))
+	BindDefault(c, Static(789))
+	assert.Equal(t, 456, Resolve[int](c),
+		"should prefer the bound provider regardless of order")
+
+	c.Freeze()
+	assert.Panics(t, func() { BindDefault(c, Static("foo")) })
+}
+
+func TestBindDefault_Extend(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	Bind(parent, Static(123))
+
+	child := parent.Extend()
+	BindDefault(child, Static(456))
+	assert.Equal(t, 123, Resolve[int](child),
+		"should prefer a parent's binding over a child's default")
+
+	parent = new(Container)
+	BindDefault(parent, Static("foo"))
+
+	child = parent.Extend()
+	assert.Equal(t, "foo", Resolve[string](child))
+
+	Bind(child, Static("bar"))
+	assert.Equal(t, "bar", Resolve[string](child))
+	assert.Equal(t, "foo", Resolve[string](parent))
+}
+
+func TestBindDefaultNamed(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindDefaultNamed(c, "x", Static(123))
+	BindNamed(c, "y", Static(456))
+	BindDefaultNamed(c, "y", Static(789))
+
+	assert.Equal(t, 123, ResolveNamed[int](c, "x"))
+	assert.Equal(t, 456, ResolveNamed[int](c, "y"))
+}
+
+func TestContainer_Strict(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+
+	BindNamed(c, "foo", Static(123))
+	BindDefaultNamed(c, "foo", Static(456))
+
+	defer func() {
+		err, ok := recover().(DuplicateBindingError)
+		require.True(t, ok, "should panic with a DuplicateBindingError")
+		assert.Contains(t, err.Existing, "container_test.go:")
+		assert.Contains(t, err.Duplicate, "container_test.go:")
+		assert.NotEqual(t, err.Existing, err.Duplicate)
+		assert.Contains(t, err.Error(), "duplicate binding for int:foo")
+	}()
+
+	BindNamed(c, "foo", Static(789))
+}
+
+func TestContainer_Strict_Defaults(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+
+	BindDefault(c, Static(123))
+	assert.Panics(t, func() { BindDefault(c, Static(456)) })
+}
+
+func TestContainer_Strict_Extend(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	parent.Strict()
+	Bind(parent, Static(123))
+
+	child := parent.Extend()
+	assert.NotPanics(t, func() { Bind(child, Static(456)) },
+		"should permit overriding a parent binding")
+	assert.Panics(t, func() { Bind(child, Static(789)) },
+		"should inherit strict mode from the parent")
+
+	lenient := new(Container)
+	assert.NotPanics(t, func() {
+		Bind(lenient, Static(123))
+		Bind(lenient, Static(456))
+	})
+	assert.Equal(t, 456, Resolve[int](lenient))
+}
+
+func TestRebind(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+
+	Bind(c, Static(123))
+	assert.NotPanics(t, func() { Rebind(c, Static(456)) })
+	assert.Equal(t, 456, Resolve[int](c))
+
+	Rebind(c, Static("foo"))
+	assert.Equal(t, "foo", Resolve[string](c), "should bind if not already bound")
+
+	c.Freeze()
+	assert.Panics(t, func() { Rebind(c, Static(789)) })
+}
+
+func TestRebindNamed(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+
+	BindNamed(c, "foo", Static(123))
+	RebindNamed(c, "foo", Static(456))
+	assert.Equal(t, 456, ResolveNamed[int](c, "foo"))
+}
+
+func TestBind_Source(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]func(c *Container){
+		"Bind":             func(c *Container) { Bind(c, Static(123)) },
+		"BindNamed":        func(c *Container) { BindNamed(c, "foo", Static(123)) },
+		"BindDefault":      func(c *Container) { BindDefault(c, Static(123)) },
+		"BindDefaultNamed": func(c *Container) { BindDefaultNamed(c, "foo", Static(123)) },
+		"Rebind":           func(c *Container) { Rebind(c, Static(123)) },
+		"RebindNamed":      func(c *Container) { RebindNamed(c, "foo", Static(123)) },
+		"BindQualified":    func(c *Container) { BindQualified[int, struct{}](c, Static(123)) },
+	}
+
+	for name, bind := range tests {
+		bind := bind
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := new(Container)
+			bind(c)
+
+			bindings := c.Bindings()
+			require.Len(t, bindings, 1)
+			assert.Contains(t, bindings[0].Source, "container_test.go:", "should record the caller")
+		})
+	}
+}
+
+func TestUnbind(t *testing.T) {
+	t.Parallel()
+
+	parent := new(Container)
+	Bind(parent, Static(123))
+
+	child := parent.Extend()
+	Bind(child, Static(456))
+	assert.Equal(t, 456, Resolve[int](child))
+
+	assert.True(t, Unbind[int](child))
+	assert.False(t, Unbind[int](child))
+	assert.Equal(t, 123, Resolve[int](child), "should not affect the parent")
+
+	child.Freeze()
+	assert.Panics(t, func() { Unbind[int](child) })
+}
+
+func TestUnbindNamed(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.Strict()
+
+	BindNamed(c, "foo", Static(123))
+	assert.True(t, UnbindNamed[int](c, "foo"))
+	assert.False(t, HasNamed[int](c, "foo"))
+	assert.NotPanics(t, func() { BindNamed(c, "foo", Static(456)) })
+}
+
+func TestContainer_MatchAssignable(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	c := new(Container)
+	Bind(c, Static(buf))
+	BindNamed(c, "foo", Static(&strings.Builder{}))
+
+	_, err := TryResolve[io.Writer](c)
+	assert.ErrorAs(t, err, &MissingProviderError{}, "should be opt-in")
+
+	c.MatchAssignable()
+	assert.Same(t, buf, Resolve[io.Writer](c))
+	assert.IsType(t, &strings.Builder{}, ResolveNamed[io.Writer](c, "foo"))
+
+	_, err = TryResolve[io.Reader](c.Extend())
+	assert.NoError(t, err, "should inherit the option when extended")
+
+	_, err = TryResolve[io.Closer](c)
+	assert.ErrorAs(t, err, &MissingProviderError{})
+
+	exact := &bytes.Buffer{}
+	Bind[io.Writer](c, Static[io.Writer](exact))
+	assert.Same(t, exact, Resolve[io.Writer](c), "should prefer exact bindings")
+}
+
+func TestContainer_MatchAssignable_Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.MatchAssignable()
+	Bind(c, Static(&bytes.Buffer{}))
+	Bind(c, Static(&strings.Builder{}))
+
+	_, err := TryResolve[io.Writer](c)
+	apErr := AmbiguousProviderError{}
+	require.ErrorAs(t, err, &apErr)
+	require.Len(t, apErr.Candidates, 2)
+	assert.Equal(t, reflect.TypeOf(&bytes.Buffer{}), apErr.Candidates[0].Type)
+	assert.Equal(t, reflect.TypeOf(&strings.Builder{}), apErr.Candidates[1].Type)
+
+	assert.Equal(t, &bytes.Buffer{}, Resolve[io.Reader](c), "should be unambiguous")
+}
@@ -28,14 +28,137 @@ func (err CircularDependencyError) Error() string {
 }
 
 // MissingProviderError is returned when calling a TryResolve* function cannot
-// find an associated ProviderFunc with the given type or name.
-type MissingProviderError typeName
+// find an associated ProviderFunc with the given type or name. Hints contains
+// additional context for diagnosing the missing provider, such as the binding
+// that required it.
+type MissingProviderError struct {
+	name  typeName
+	Hints []string
+}
 
 func (err MissingProviderError) Error() string {
-	return fmt.Sprintf("missing provider for %v", typeName(err))
+	if len(err.Hints) == 0 {
+		return fmt.Sprintf("missing provider for %v", err.name)
+	}
+
+	builder := &strings.Builder{}
+	_, _ = fmt.Fprintf(builder, "missing provider for %v:", err.name)
+
+	for _, hint := range err.Hints {
+		_, _ = fmt.Fprintf(builder, "\n- %s", hint)
+	}
+
+	return builder.String()
+}
+
+// ResolveError is returned when calling a TryResolve* function results in a
+// ProviderFunc returning an error. It wraps the error from the provider along
+// with the chain of types and names being resolved when it occurred, starting
+// with the outermost. ResolveError supports errors.Is and errors.As via Unwrap.
+type ResolveError struct {
+	chain []typeName
+	Err   error
+}
+
+func (err ResolveError) Error() string {
+	switch len(err.chain) {
+	case 0:
+		return fmt.Sprintf("error resolving: %v", err.Err)
+	case 1:
+		return fmt.Sprintf("error resolving %v: %v", err.chain[0], err.Err)
+	default:
+		builder := &strings.Builder{}
+		_, _ = fmt.Fprintf(builder, "error resolving %v:", err.chain[0])
+
+		for _, tn := range err.chain[1:] {
+			_, _ = fmt.Fprintf(builder, "\n- depends on %v", tn)
+		}
+		_, _ = fmt.Fprintf(builder, "\n- failed: %v", err.Err)
+
+		return builder.String()
+	}
+}
+
+func (err ResolveError) Unwrap() error {
+	return err.Err
+}
+
+// ProviderPanicError is returned when calling a TryResolve* function results in
+// a ProviderFunc panicking. It contains the recovered panic value, the stack
+// trace at the time of the panic, and the chain of types and names being
+// resolved, starting with the outermost. If the panic value is an error, it is
+// available via Unwrap. Recovery can be disabled with Container.Repanic.
+type ProviderPanicError struct {
+	chain []typeName
+	Value any
+	Stack []byte
+}
+
+func (err ProviderPanicError) Error() string {
+	switch len(err.chain) {
+	case 0:
+		return fmt.Sprintf("panic resolving: %v", err.Value)
+	case 1:
+		return fmt.Sprintf("panic resolving %v: %v", err.chain[0], err.Value)
+	default:
+		builder := &strings.Builder{}
+		_, _ = fmt.Fprintf(builder, "panic resolving %v:", err.chain[0])
+
+		for _, tn := range err.chain[1:] {
+			_, _ = fmt.Fprintf(builder, "\n- depends on %v", tn)
+		}
+		_, _ = fmt.Fprintf(builder, "\n- panicked: %v", err.Value)
+
+		return builder.String()
+	}
+}
+
+func (err ProviderPanicError) Unwrap() error {
+	if e, ok := err.Value.(error); ok {
+		return e
+	}
+	return nil
+}
+
+// AmbiguousProviderError is returned when calling a TryResolve* function on a
+// Container with MatchAssignable enabled finds more than one binding whose type
+// implements the requested interface type.
+type AmbiguousProviderError struct {
+	name       typeName
+	Candidates []Binding
+}
+
+func (err AmbiguousProviderError) Error() string {
+	builder := &strings.Builder{}
+	_, _ = fmt.Fprintf(builder, "ambiguous provider for %v:", err.name)
+
+	for _, b := range err.Candidates {
+		_, _ = fmt.Fprintf(builder, "\n- candidate %v (bound at %s)", b.typeName(), b.Source)
+	}
+
+	return builder.String()
+}
+
+// DuplicateBindingError is the panic value when a provider is bound to a
+// Container in strict mode that already has a provider for the same type and
+// name. Existing and Duplicate are the file:line call sites of the original
+// and conflicting bindings, respectively.
+type DuplicateBindingError struct {
+	name      typeName
+	Existing  string
+	Duplicate string
+}
+
+func (err DuplicateBindingError) Error() string {
+	return fmt.Sprintf("duplicate binding for %v at %s (previously bound at %s)",
+		err.name, err.Duplicate, err.Existing)
 }
 
 var (
 	_ error = CircularDependencyError{}
 	_ error = MissingProviderError{}
+	_ error = DuplicateBindingError{}
+	_ error = ResolveError{}
+	_ error = ProviderPanicError{}
+	_ error = AmbiguousProviderError{}
 )
@@ -1,7 +1,11 @@
 package ioc
 
 import (
+	"bytes"
+	"errors"
 	"io"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,6 +56,126 @@ func TestCircularDependencyError_Error(t *testing.T) {
 func TestMissingProviderError_Error(t *testing.T) {
 	t.Parallel()
 
-	err := MissingProviderError(newTypeName[io.Writer]("w"))
-	assert.Contains(t, err.Error(), "missing provider for io.Writer:w")
+	err := MissingProviderError{name: newTypeName[io.Writer]("w")}
+	assert.Equal(t, "missing provider for io.Writer:w", err.Error())
+
+	err.Hints = []string{"foo", "bar"}
+	assert.Equal(t, "missing provider for io.Writer:w:\n- foo\n- bar", err.Error())
+}
+
+func TestDuplicateBindingError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := DuplicateBindingError{
+		name:      newTypeName[int]("foo"),
+		Existing:  "a.go:1",
+		Duplicate: "b.go:2",
+	}
+	assert.Equal(t, "duplicate binding for int:foo at b.go:2 (previously bound at a.go:1)", err.Error())
+}
+
+func TestResolveError_Error(t *testing.T) {
+	t.Parallel()
+
+	exErr := errors.New("some error")
+
+	tests := []struct {
+		name  string
+		err   ResolveError
+		exMsg string
+	}{
+		{
+			name:  "empty",
+			err:   ResolveError{Err: exErr},
+			exMsg: "error resolving: some error",
+		},
+		{
+			name:  "one",
+			err:   ResolveError{chain: []typeName{newTypeName[uint](anonymous)}, Err: exErr},
+			exMsg: "error resolving uint: some error",
+		},
+		{
+			name: "many",
+			err: ResolveError{
+				chain: []typeName{
+					newTypeName[int]("foo"),
+					newTypeName[string]("bar"),
+				},
+				Err: exErr,
+			},
+			exMsg: "error resolving int:foo:\n- depends on string:bar\n- failed: some error",
+		},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.exMsg, tc.err.Error())
+			assert.ErrorIs(t, tc.err, exErr)
+		})
+	}
+}
+
+func TestProviderPanicError_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		err   ProviderPanicError
+		exMsg string
+	}{
+		{
+			name:  "empty",
+			err:   ProviderPanicError{Value: "oh no"},
+			exMsg: "panic resolving: oh no",
+		},
+		{
+			name:  "one",
+			err:   ProviderPanicError{chain: []typeName{newTypeName[uint](anonymous)}, Value: "oh no"},
+			exMsg: "panic resolving uint: oh no",
+		},
+		{
+			name: "many",
+			err: ProviderPanicError{
+				chain: []typeName{
+					newTypeName[int]("foo"),
+					newTypeName[string]("bar"),
+				},
+				Value: "oh no",
+			},
+			exMsg: "panic resolving int:foo:\n- depends on string:bar\n- panicked: oh no",
+		},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.exMsg, tc.err.Error())
+		})
+	}
+}
+
+func TestProviderPanicError_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	exErr := errors.New("some error")
+	assert.ErrorIs(t, ProviderPanicError{Value: exErr}, exErr)
+	assert.NoError(t, ProviderPanicError{Value: "oh no"}.Unwrap())
+}
+
+func TestAmbiguousProviderError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := AmbiguousProviderError{
+		name: newTypeName[io.Writer]("w"),
+		Candidates: []Binding{
+			{Type: reflect.TypeOf(&bytes.Buffer{}), Name: "w", Source: "a.go:1"},
+			{Type: reflect.TypeOf(&strings.Builder{}), Name: "w", Source: "b.go:2"},
+		},
+	}
+	assert.Equal(t, "ambiguous provider for io.Writer:w:\n"+
+		"- candidate *bytes.Buffer:w (bound at a.go:1)\n"+
+		"- candidate *strings.Builder:w (bound at b.go:2)", err.Error())
 }
@@ -0,0 +1,28 @@
+package ioc
+
+import "context"
+
+// Factory creates a new value of type T each time it is called by resolving T
+// from the Container that produced the Factory. A Factory[T] can be resolved
+// from any Container without an explicit binding; the name or qualifier used to
+// resolve the Factory[T] is the one used to resolve T.
+//
+// Factory is useful for components that need to repeatedly construct transient
+// values (eg, one per job) without holding a reference to the Container
+// itself. Unless T is bound as a Singleton, each call produces a new value.
+type Factory[T any] func(ctx context.Context) (T, error)
+
+func newFactory[T any](c *Container, name typeName) Factory[T] {
+	c = c.unwind()
+	return func(ctx context.Context) (T, error) {
+		return tryResolve[T](ctx, c, name)
+	}
+}
+
+func (Factory[T]) implicitProvider(tn typeName) providerFunc {
+	return func(c *Container) (any, error) {
+		return newFactory[T](c, retype[T](tn)), nil
+	}
+}
+
+var _ implicitBinding = Factory[any](nil)
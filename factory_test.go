@@ -0,0 +1,99 @@
+package ioc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transient", func(t *testing.T) {
+		t.Parallel()
+
+		counter := 0
+		c := new(Container)
+		Bind(c, Infallible(func(*Container) int {
+			counter++
+			return counter
+		}))
+
+		factory, err := TryResolve[Factory[int]](c)
+		require.NoError(t, err)
+		assert.Zero(t, counter)
+
+		for i := 1; i <= 3; i++ {
+			v, err := factory(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, i, v)
+		}
+	})
+
+	t.Run("named", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "foo", Static("bar"))
+
+		v, err := ResolveNamed[Factory[string]](c, "foo")(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", v)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "foo", "bar")
+
+		c := new(Container)
+		var outFoo any
+		Bind(c, Infallible(func(c *Container) int {
+			outFoo = c.Context().Value("foo")
+			return 123
+		}))
+
+		_, err := Resolve[Factory[int]](c)(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", outFoo)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		factory, err := TryResolve[Factory[int]](c)
+		require.NoError(t, err)
+
+		v, err := factory(context.Background())
+		assert.Zero(t, v)
+		assert.ErrorAs(t, err, &MissingProviderError{})
+	})
+
+	t.Run("dependency", func(t *testing.T) {
+		t.Parallel()
+
+		type worker struct{ id int }
+		type pool struct{ newWorker Factory[*worker] }
+
+		counter := 0
+		c := new(Container)
+		Bind(c, Infallible(func(*Container) *worker {
+			counter++
+			return &worker{id: counter}
+		}))
+		Bind(c, func(c *Container) (*pool, error) {
+			f, err := TryResolve[Factory[*worker]](c)
+			return &pool{newWorker: f}, err
+		})
+
+		p := Resolve[*pool](c)
+		w1, err := p.newWorker(context.Background())
+		require.NoError(t, err)
+		w2, err := p.newWorker(context.Background())
+		require.NoError(t, err)
+		assert.NotSame(t, w1, w2)
+	})
+}
@@ -0,0 +1,115 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Graph is the dependency graph of a Container, discovered by resolving each
+// of its bindings.
+type Graph struct {
+	// Nodes contains an entry for each binding visible from the Container
+	// that is not shadowed, in the same order as Container.Bindings.
+	Nodes []GraphNode
+}
+
+// GraphNode is a binding in a Graph along with the dependencies it resolved.
+type GraphNode struct {
+	Binding
+	// Dependencies are the types and names (formatted like "*sql.DB:primary")
+	// the binding's provider has resolved, sorted and without duplicates. This
+	// includes dependencies that could not be resolved.
+	Dependencies []string
+	// Err is the error, if any, from resolving the binding.
+	Err error
+}
+
+// Graph resolves every binding visible from the Container that is not
+// shadowed, reporting the dependencies each provider has resolved. Since every
+// provider is called (including Singletons, which will be initialized), Graph
+// is primarily intended for tests and diagnostics. Errors resolving a binding
+// are recorded on its GraphNode.
+//
+// To keep resolution cheap, dependencies are only recorded while Graph is
+// resolving, and by Singletons when they are initialized. The dependencies of
+// a provider that caches its value by other means are not reported if it was
+// resolved before Graph was called.
+func (c *Container) Graph(ctx context.Context) Graph {
+	g := Graph{}
+	for _, b := range c.Bindings() {
+		if b.Shadowed {
+			continue
+		}
+
+		tn := b.typeName()
+		node := GraphNode{Binding: b}
+		reg, err := c.lookup(tn)
+		if err == nil {
+			_, err = c.resolve(ctx, tn, reg, true)
+			node.Dependencies = reg.deps.list()
+		}
+		node.Err = err
+
+		g.Nodes = append(g.Nodes, node)
+	}
+	return g
+}
+
+// String renders the Graph deterministically, suitable for comparison with a
+// golden file. Each binding is listed followed by its direct dependencies and
+// the first line of any error resolving it. Source locations are omitted so the
+// output is unaffected by unrelated changes to the files binding providers.
+func (g Graph) String() string {
+	builder := &strings.Builder{}
+	for _, n := range g.Nodes {
+		_, _ = fmt.Fprintf(builder, "%v\n", n.typeName())
+		for _, dep := range n.Dependencies {
+			_, _ = fmt.Fprintf(builder, "- depends on %s\n", dep)
+		}
+		if n.Err != nil {
+			msg, _, _ := strings.Cut(n.Err.Error(), "\n")
+			_, _ = fmt.Fprintf(builder, "- error: %s\n", msg)
+		}
+	}
+	return builder.String()
+}
+
+// dependencySet records the types and names resolved by the provider of a
+// registration, across the calls to it that record dependencies.
+type dependencySet struct {
+	mu   sync.Mutex
+	deps map[typeName]struct{}
+}
+
+// add records name as a dependency. It is a no-op on a nil dependencySet, such
+// as that of a Container not recording dependencies.
+func (s *dependencySet) add(name typeName) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deps == nil {
+		s.deps = map[typeName]struct{}{}
+	}
+	s.deps[name] = struct{}{}
+}
+
+func (s *dependencySet) list() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for dep := range s.deps {
+		out = append(out, dep.String())
+	}
+	sort.Strings(out)
+	return out
+}
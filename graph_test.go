@@ -0,0 +1,140 @@
+package ioc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	graphA struct{ b *graphB }
+	graphB struct{ a *graphA }
+)
+
+func TestContainer_Graph(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+	BindNamed(c, "foo", Static("bar"))
+	Bind(c, Singleton(func(c *Container) (float64, error) {
+		i, err := TryResolve[int](c)
+		if err != nil {
+			return 0, err
+		}
+		s, err := TryResolveNamed[string](c, "foo")
+		return float64(i + len(s)), err
+	}))
+	Bind(c, Infallible(func(c *Container) bool {
+		f := Resolve[float64](c)
+		_, _ = TryResolve[int](c)
+		return f > 0
+	}))
+	Bind(c, ProviderFunc[uint](func(c *Container) (uint, error) {
+		_, err := TryResolve[int8](c)
+		return 0, err
+	}))
+
+	ctx := context.Background()
+	g := c.Graph(ctx)
+	require.Len(t, g.Nodes, 5)
+
+	deps := map[string][]string{}
+	for _, n := range g.Nodes {
+		deps[n.typeName().String()] = n.Dependencies
+	}
+	assert.Equal(t, map[string][]string{
+		"bool":       {"float64", "int"},
+		"float64":    {"int", "string:foo"},
+		"int":        nil,
+		"string:foo": nil,
+		"uint":       {"int8"},
+	}, deps)
+
+	assert.Equal(t, `bool
+- depends on float64
+- depends on int
+float64
+- depends on int
+- depends on string:foo
+int
+string:foo
+uint
+- depends on int8
+- error: error resolving uint: missing provider for int8:
+`, g.String())
+
+	assert.Equal(t, g, c.Graph(ctx), "should be deterministic")
+}
+
+func TestContainer_Graph_Cycle(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Singleton(func(c *Container) (*graphA, error) {
+		b, err := TryResolve[*graphB](c)
+		return &graphA{b: b}, err
+	}))
+	Bind(c, Singleton(func(c *Container) (*graphB, error) {
+		a, err := TryResolve[*graphA](c)
+		return &graphB{a: a}, err
+	}))
+
+	g := c.Graph(context.Background())
+	require.Len(t, g.Nodes, 2)
+	for _, n := range g.Nodes {
+		assert.Len(t, n.Dependencies, 1)
+		assert.True(t, errors.As(n.Err, &CircularDependencyError{}), n.Err)
+	}
+}
+
+func TestContainer_Graph_Shadowed(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+
+	child := c.Extend()
+	Bind(child, Static(456))
+
+	g := child.Graph(context.Background())
+	require.Len(t, g.Nodes, 1)
+	assert.Zero(t, g.Nodes[0].Depth)
+}
+
+func TestContainer_Graph_Recording(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+	BindNamed(c, "transient", Infallible(func(c *Container) string {
+		return fmt.Sprint(Resolve[int](c))
+	}))
+	BindNamed(c, "singleton", Singleton(func(c *Container) (string, error) {
+		i, err := TryResolve[int](c)
+		return fmt.Sprint(i), err
+	}))
+
+	for _, name := range []string{"transient", "singleton"} {
+		ResolveNamed[string](c, name)
+	}
+
+	reg, err := c.lookup(newTypeName[string]("transient"))
+	require.NoError(t, err)
+	assert.Empty(t, reg.deps.list(), "should not record outside of Graph")
+
+	reg, err = c.lookup(newTypeName[string]("singleton"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"int"}, reg.deps.list(), "should record when a Singleton is initialized")
+
+	for _, n := range c.Graph(context.Background()).Nodes {
+		if n.Type == reflect.TypeOf("") {
+			assert.Equal(t, []string{"int"}, n.Dependencies, n.Name)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package callsite identifies the file:line that bound a provider to an ioc
+// Container. Functions that bind providers on behalf of their callers, such as
+// those in the config package, mark themselves via Helper so that the
+// recorded call site refers to their caller instead, much like
+// testing.T.Helper.
+package callsite
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// maxDepth is the maximum number of frames Source inspects.
+const maxDepth = 32
+
+// helpers is the set of function names marked via Helper.
+var helpers sync.Map
+
+// Helper marks the function calling Helper as a helper. Its frames are
+// skipped by Source.
+func Helper() {
+	var pc [1]uintptr
+	if runtime.Callers(2, pc[:]) == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pc[:]).Next()
+	helpers.LoadOrStore(frame.Function, struct{}{})
+}
+
+// Source returns the file:line of the caller skip frames above the function
+// calling Source, skipping any frames of functions marked via Helper. If no
+// such frame exists, "unknown" is returned.
+func Source(skip int) string {
+	var pcs [maxDepth]uintptr
+	n := runtime.Callers(skip+3, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if _, helper := helpers.Load(frame.Function); !helper && frame.File != "" {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
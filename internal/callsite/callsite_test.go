@@ -0,0 +1,48 @@
+package callsite
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func source() string { return Source(0) }
+
+func helper() string {
+	Helper()
+	return source()
+}
+
+func nestedHelper() string {
+	Helper()
+	return helper()
+}
+
+func notHelper() string {
+	return helper()
+}
+
+// here returns the file:line of its caller.
+func here() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	got, want := source(), here()
+	assert.Equal(t, want, got, "should return the caller")
+
+	got, want = helper(), here()
+	assert.Equal(t, want, got, "should skip helpers")
+
+	got, want = nestedHelper(), here()
+	assert.Equal(t, want, got, "should skip nested helpers")
+
+	got, want = notHelper(), here()
+	assert.NotEqual(t, want, got, "should not skip unmarked callers")
+	assert.Contains(t, got, "callsite_test.go:")
+}
@@ -0,0 +1,120 @@
+package ioctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rodaine/ioc"
+)
+
+// AssertResolvableNamed asserts that a value for type T with the specified
+// name can be resolved from c, reporting the error (including the full
+// resolving chain) otherwise. The result of the assertion is returned.
+func AssertResolvableNamed[T any](t testing.TB, c *ioc.Container, name string) bool {
+	t.Helper()
+
+	if _, err := ioc.TryResolveNamed[T](c, name); err != nil {
+		t.Errorf("ioctest: expected to resolve: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// AssertResolvable asserts that a value for type T can be resolved from c. It
+// is equivalent to calling AssertResolvableNamed with an empty name argument.
+func AssertResolvable[T any](t testing.TB, c *ioc.Container) bool {
+	t.Helper()
+	return AssertResolvableNamed[T](t, c, "")
+}
+
+// AssertNotResolvableNamed asserts that resolving a value for type T with the
+// specified name from c fails with an ioc.MissingProviderError, either for T
+// itself or one of its dependencies. Any other outcome, including a different
+// error, fails the assertion. The result of the assertion is returned.
+func AssertNotResolvableNamed[T any](t testing.TB, c *ioc.Container, name string) bool {
+	t.Helper()
+
+	_, err := ioc.TryResolveNamed[T](c, name)
+	switch {
+	case err == nil:
+		t.Errorf("ioctest: expected missing provider resolving %T, but resolved successfully", *new(T))
+		return false
+	case !errors.As(err, &ioc.MissingProviderError{}):
+		t.Errorf("ioctest: expected missing provider, got: %v", err)
+		return false
+	default:
+		return true
+	}
+}
+
+// AssertNotResolvable asserts that resolving a value for type T from c fails
+// with an ioc.MissingProviderError. It is equivalent to calling
+// AssertNotResolvableNamed with an empty name argument.
+func AssertNotResolvable[T any](t testing.TB, c *ioc.Container) bool {
+	t.Helper()
+	return AssertNotResolvableNamed[T](t, c, "")
+}
+
+// AssertSingletonNamed asserts that resolving a value for type T with the
+// specified name from c twice produces the same value, as is the case for a
+// provider wrapped with ioc.Singleton. T must be a comparable type, such as a
+// pointer; values are compared with ==. The result of the assertion is
+// returned.
+func AssertSingletonNamed[T comparable](t testing.TB, c *ioc.Container, name string) bool {
+	t.Helper()
+
+	first, err := ioc.TryResolveNamed[T](c, name)
+	if err != nil {
+		t.Errorf("ioctest: expected to resolve: %v", err)
+		return false
+	}
+
+	second, err := ioc.TryResolveNamed[T](c, name)
+	if err != nil {
+		t.Errorf("ioctest: expected to resolve: %v", err)
+		return false
+	}
+
+	if first != second {
+		t.Errorf("ioctest: expected the same %T value from each resolution, got %v and %v", first, first, second)
+		return false
+	}
+
+	return true
+}
+
+// AssertSingleton asserts that resolving a value for type T from c twice
+// produces the same value. It is equivalent to calling AssertSingletonNamed
+// with an empty name argument.
+func AssertSingleton[T comparable](t testing.TB, c *ioc.Container) bool {
+	t.Helper()
+	return AssertSingletonNamed[T](t, c, "")
+}
+
+// AssertNoCycles asserts that none of the bindings visible from c have a
+// dependency cycle, reporting each binding that fails to resolve with an
+// ioc.CircularDependencyError. Like ioc.Container.Graph, every provider is
+// called. The result of the assertion is returned.
+func AssertNoCycles(t testing.TB, c *ioc.Container) bool {
+	t.Helper()
+
+	ok := true
+	for _, n := range c.Graph(context.Background()).Nodes {
+		if errors.As(n.Err, &ioc.CircularDependencyError{}) {
+			t.Errorf("ioctest: %v", n.Err)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// AssertGraphEquals asserts that the dependency graph of c, as rendered by
+// ioc.Graph.String, matches the golden file at path. Like ioc.Container.Graph,
+// every provider is called. See AssertGolden for regenerating the golden file.
+func AssertGraphEquals(t testing.TB, c *ioc.Container, path string) bool {
+	t.Helper()
+	return AssertGolden(t, path, c.Graph(context.Background()).String())
+}
@@ -0,0 +1,123 @@
+package ioctest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	cycleA struct{ b *cycleB }
+	cycleB struct{ a *cycleA }
+)
+
+func TestAssertResolvable(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.Bind(c, ioc.Static(123))
+	ioc.BindNamed(c, "foo", ioc.Static("bar"))
+
+	ft := &fakeT{}
+	assert.True(t, AssertResolvable[int](ft, c))
+	assert.True(t, AssertResolvableNamed[string](ft, c, "foo"))
+	assert.False(t, ft.failed)
+
+	assert.False(t, AssertResolvable[string](ft, c))
+	assert.True(t, ft.failed)
+	require.Len(t, ft.messages, 1)
+	assert.Contains(t, ft.messages[0], "missing provider for string")
+}
+
+func TestAssertNotResolvable(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.Bind(c, ioc.Static(123))
+	ioc.Bind(c, ioc.ProviderFunc[string](func(c *ioc.Container) (string, error) {
+		_, err := ioc.TryResolve[bool](c)
+		return "", err
+	}))
+	ioc.Bind(c, ioc.Infallible(func(*ioc.Container) float64 { panic("oh no") }))
+
+	ft := &fakeT{}
+	assert.True(t, AssertNotResolvable[bool](ft, c))
+	assert.True(t, AssertNotResolvableNamed[int](ft, c, "foo"))
+	assert.True(t, AssertNotResolvable[string](ft, c), "missing dependency")
+	assert.False(t, ft.failed)
+
+	assert.False(t, AssertNotResolvable[int](ft, c))
+	assert.False(t, AssertNotResolvable[float64](ft, c))
+	require.Len(t, ft.messages, 2)
+	assert.Equal(t, "ioctest: expected missing provider resolving int, but resolved successfully", ft.messages[0])
+	assert.Contains(t, ft.messages[1], "oh no")
+}
+
+func TestAssertSingleton(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.Bind(c, ioc.Singleton(func(*ioc.Container) (*cycleA, error) { return &cycleA{}, nil }))
+	ioc.BindNamed(c, "foo", ioc.Infallible(func(*ioc.Container) *cycleA { return &cycleA{} }))
+
+	ft := &fakeT{}
+	assert.True(t, AssertSingleton[*cycleA](ft, c))
+	assert.False(t, ft.failed)
+
+	assert.False(t, AssertSingletonNamed[*cycleA](ft, c, "foo"))
+	assert.False(t, AssertSingleton[*cycleB](ft, c))
+	require.Len(t, ft.messages, 2)
+	assert.Contains(t, ft.messages[0], "expected the same *ioctest.cycleA value")
+	assert.Contains(t, ft.messages[1], "missing provider for *ioctest.cycleB")
+}
+
+func TestAssertNoCycles(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.Bind(c, ioc.Static(123))
+
+	ft := &fakeT{}
+	assert.True(t, AssertNoCycles(ft, c))
+	assert.False(t, ft.failed)
+
+	ioc.Bind(c, ioc.ProviderFunc[*cycleA](func(c *ioc.Container) (*cycleA, error) {
+		b, err := ioc.TryResolve[*cycleB](c)
+		return &cycleA{b: b}, err
+	}))
+	ioc.Bind(c, ioc.ProviderFunc[*cycleB](func(c *ioc.Container) (*cycleB, error) {
+		a, err := ioc.TryResolve[*cycleA](c)
+		return &cycleB{a: a}, err
+	}))
+
+	assert.False(t, AssertNoCycles(ft, c))
+	assert.Len(t, ft.messages, 2, "should report each binding in the cycle")
+}
+
+func TestAssertGraphEquals(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.BindNamed(c, "foo", ioc.Static("bar"))
+	ioc.Bind(c, ioc.Infallible(func(c *ioc.Container) int {
+		return len(ioc.ResolveNamed[string](c, "foo"))
+	}))
+
+	path := filepath.Join(t.TempDir(), "graph.golden")
+	require.NoError(t, os.WriteFile(path, []byte("int\n- depends on string:foo\nstring:foo\n"), 0o600))
+
+	ft := &fakeT{}
+	assert.True(t, AssertGraphEquals(ft, c, path))
+	assert.False(t, ft.failed)
+
+	ioc.Bind(c, ioc.Static(true))
+	assert.False(t, AssertGraphEquals(ft, c, path))
+	assert.False(t, AssertGraphEquals(ft, c, filepath.Join(t.TempDir(), "missing.golden")))
+	require.Len(t, ft.messages, 2)
+	assert.Contains(t, ft.messages[0], "graph.golden does not match")
+	assert.Contains(t, ft.messages[1], "reading golden file")
+}
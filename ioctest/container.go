@@ -0,0 +1,94 @@
+package ioctest
+
+import (
+	"testing"
+
+	"github.com/rodaine/ioc"
+)
+
+// Option configures a test Container created by New.
+type Option func(c *ioc.Container)
+
+// Override replaces the anonymous binding for T with a static value.
+func Override[T any](value T) Option {
+	return OverrideProvider(ioc.Static(value))
+}
+
+// OverrideNamed replaces the binding for T with the specified name with a
+// static value.
+func OverrideNamed[T any](name string, value T) Option {
+	return OverrideNamedProvider(name, ioc.Static(value))
+}
+
+// OverrideProvider replaces the anonymous binding for T with fn.
+func OverrideProvider[T any](fn ioc.ProviderFunc[T]) Option {
+	return func(c *ioc.Container) { ioc.Rebind(c, fn) }
+}
+
+// OverrideNamedProvider replaces the binding for T with the specified name
+// with fn.
+func OverrideNamedProvider[T any](name string, fn ioc.ProviderFunc[T]) Option {
+	return func(c *ioc.Container) { ioc.RebindNamed(c, name, fn) }
+}
+
+// New creates a Container for a test by extending base (which may be frozen)
+// and applying the opts to it. The returned Container is frozen, and is closed
+// when the test completes; an error closing it fails the test. If base is nil,
+// a new, empty Container is used instead.
+func New(t testing.TB, base *ioc.Container, opts ...Option) *ioc.Container {
+	t.Helper()
+
+	c := new(ioc.Container)
+	if base != nil {
+		c = base.Extend()
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Freeze()
+
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("ioctest: closing container: %v", err)
+		}
+	})
+
+	return c
+}
+
+// ResolveNamed resolves a value for type T with the specified name, failing
+// the test immediately if an error occurs. Unlike ioc.ResolveNamed, the error
+// (including the full resolving chain) is reported via t.Fatal rather than a
+// panic.
+func ResolveNamed[T any](t testing.TB, c *ioc.Container, name string) T {
+	t.Helper()
+
+	value, err := ioc.TryResolveNamed[T](c, name)
+	if err != nil {
+		t.Fatalf("ioctest: %v", err)
+	}
+
+	return value
+}
+
+// Resolve resolves a value for type T, failing the test immediately if an
+// error occurs. It is equivalent to calling ResolveNamed with an empty name
+// argument.
+func Resolve[T any](t testing.TB, c *ioc.Container) T {
+	t.Helper()
+	return ResolveNamed[T](t, c, "")
+}
+
+// ResolveQualified resolves a value for type T with the qualifier Q, failing
+// the test immediately if an error occurs.
+func ResolveQualified[T, Q any](t testing.TB, c *ioc.Container) T {
+	t.Helper()
+
+	value, err := ioc.TryResolveQualified[T, Q](c)
+	if err != nil {
+		t.Fatalf("ioctest: %v", err)
+	}
+
+	return value
+}
@@ -0,0 +1,94 @@
+package ioctest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type primary struct{}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	base := new(ioc.Container)
+	ioc.Bind(base, ioc.Static(123))
+	ioc.BindNamed(base, "foo", ioc.Static("bar"))
+	ioc.BindQualified[bool, primary](base, ioc.Static(false))
+	base.Freeze()
+
+	ft := &fakeT{}
+	c := New(ft, base,
+		Override(456),
+		OverrideNamed("foo", "baz"),
+		OverrideProvider(ioc.Infallible(func(*ioc.Container) float64 { return 1.5 })),
+	)
+
+	assert.Equal(t, 456, Resolve[int](t, c))
+	assert.Equal(t, "baz", ResolveNamed[string](t, c, "foo"))
+	assert.Equal(t, 1.5, Resolve[float64](t, c))
+	assert.False(t, ResolveQualified[bool, primary](t, c))
+	assert.Equal(t, 123, ioc.Resolve[int](base), "should not modify the base")
+	assert.Panics(t, func() { ioc.Bind(c, ioc.Static(true)) }, "should be frozen")
+
+	closed := false
+	c.OnClose(func() error { closed = true; return nil })
+	ft.cleanup()
+	assert.True(t, closed)
+	assert.False(t, ft.failed)
+}
+
+func TestNew_NilBase(t *testing.T) {
+	t.Parallel()
+
+	c := New(t, nil, OverrideNamedProvider("x", ioc.Static(42)))
+	assert.Equal(t, 42, ResolveNamed[int](t, c, "x"))
+}
+
+func TestNew_CloseError(t *testing.T) {
+	t.Parallel()
+
+	ft := &fakeT{}
+	c := New(ft, nil)
+	c.OnClose(func() error { return errors.New("oh no") })
+
+	ft.cleanup()
+	assert.True(t, ft.failed)
+	assert.Equal(t, []string{"ioctest: closing container: oh no"}, ft.messages)
+}
+
+func TestResolve_Fatal(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.Bind(c, func(c *ioc.Container) (int, error) {
+		s, err := ioc.TryResolve[string](c)
+		return len(s), err
+	})
+
+	tests := map[string]func(t testing.TB){
+		"Resolve":          func(t testing.TB) { Resolve[int](t, c) },
+		"ResolveNamed":     func(t testing.TB) { ResolveNamed[int](t, c, "") },
+		"ResolveQualified": func(t testing.TB) { ResolveQualified[string, primary](t, c) },
+	}
+
+	for name, test := range tests {
+		fn := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ft := &fakeT{}
+			ft.run(fn)
+			assert.True(t, ft.fatal)
+			require.Len(t, ft.messages, 1)
+			assert.Contains(t, ft.messages[0], "ioctest: ")
+		})
+	}
+
+	ft := &fakeT{}
+	ft.run(func(t testing.TB) { Resolve[int](t, c) })
+	assert.Contains(t, ft.messages[0], "error resolving int: missing provider for string")
+}
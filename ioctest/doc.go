@@ -0,0 +1,5 @@
+// Package ioctest provides utilities for testing code that uses ioc
+// Containers, such as creating isolated test Containers with overridden
+// bindings, asserting on the resolvability and dependency graph of their
+// bindings, and comparing them against golden files.
+package ioctest
@@ -0,0 +1,57 @@
+package ioctest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// fakeT records failures reported by the helpers under test without failing
+// the real test.
+type fakeT struct {
+	testing.TB
+	mu       sync.Mutex
+	failed   bool
+	fatal    bool
+	messages []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = true
+	f.messages = append(f.messages, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	f.mu.Lock()
+	f.fatal = true
+	f.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) cleanup() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+// run calls fn with the fakeT in a separate goroutine, so that Fatalf can
+// halt it without halting the real test.
+func (f *fakeT) run(fn func(t testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(f)
+	}()
+	<-done
+}
@@ -0,0 +1,66 @@
+package ioctest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rodaine/ioc"
+)
+
+// update is set by passing -ioctest.update to a test binary, causing golden
+// files to be rewritten rather than compared against. Since only test binaries
+// importing ioctest define the flag, it should be scoped to those packages:
+//
+//	go test ./pkg/... -ioctest.update
+var update = flag.Bool("ioctest.update", false, "rewrite golden files used by ioctest assertions")
+
+// AssertGolden asserts that got matches the contents of the golden file at
+// path. If the test binary is run with the -ioctest.update flag, the golden
+// file (and any missing parent directories) is written with got instead. The
+// result of the assertion is returned.
+func AssertGolden(t testing.TB, path, got string) bool {
+	t.Helper()
+	return assertGolden(t, path, got, *update)
+}
+
+func assertGolden(t testing.TB, path, got string, update bool) bool {
+	t.Helper()
+
+	if update {
+		if err := writeGolden(path, got); err != nil {
+			t.Errorf("ioctest: updating golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("ioctest: reading golden file (run with -ioctest.update to create it): %v", err)
+		return false
+	}
+
+	if got != string(want) {
+		t.Errorf("ioctest: %s does not match (run with -ioctest.update to regenerate it)\ngot:\n%s\nwant:\n%s", path, got, want)
+		return false
+	}
+
+	return true
+}
+
+func writeGolden(path, contents string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(contents), 0o600)
+}
+
+// AssertSnapshot asserts that the bindings of c, as rendered by
+// ioc.Container.Snapshot, match the golden file at path. See AssertGolden for
+// regenerating the golden file.
+func AssertSnapshot(t testing.TB, c *ioc.Container, path string) bool {
+	t.Helper()
+	return AssertGolden(t, path, c.Snapshot())
+}
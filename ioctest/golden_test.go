@@ -0,0 +1,56 @@
+package ioctest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGolden(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "testdata", "foo.golden")
+
+	ft := &fakeT{}
+	assert.False(t, AssertGolden(ft, path, "foo\n"))
+	require.Len(t, ft.messages, 1)
+	assert.Contains(t, ft.messages[0], "run with -ioctest.update to create it")
+
+	ft = &fakeT{}
+	assert.True(t, assertGolden(ft, path, "foo\n", true), "should create the file and its directory")
+	assert.True(t, AssertGolden(ft, path, "foo\n"))
+	assert.False(t, ft.failed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(data))
+
+	assert.False(t, AssertGolden(ft, path, "bar\n"))
+	require.Len(t, ft.messages, 1)
+	assert.Contains(t, ft.messages[0], "run with -ioctest.update to regenerate it")
+
+	assert.True(t, assertGolden(ft, path, "bar\n", true), "should overwrite the file")
+	assert.True(t, AssertGolden(ft, path, "bar\n"))
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	t.Parallel()
+
+	c := new(ioc.Container)
+	ioc.BindNamed(c, "foo", ioc.Static("bar"))
+
+	path := filepath.Join(t.TempDir(), "snapshot.golden")
+	ft := &fakeT{}
+	require.True(t, assertGolden(ft, path, c.Snapshot(), true))
+	assert.True(t, AssertSnapshot(ft, c, path))
+	assert.False(t, ft.failed)
+
+	ioc.Bind(c, ioc.Static(123))
+	assert.False(t, AssertSnapshot(ft, c, path))
+	require.Len(t, ft.messages, 1)
+	assert.Contains(t, ft.messages[0], "int static at ioctest/golden_test.go:")
+}
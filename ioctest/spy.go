@@ -0,0 +1,101 @@
+package ioctest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rodaine/ioc"
+)
+
+// Call records a single call to a SpyProvider.
+type Call struct {
+	// Context is the context.Context of the resolution, as returned by
+	// ioc.Container.Context.
+	Context context.Context
+	// Chain is the resolving chain of the call, as returned by
+	// ioc.Container.Chain. The last element is the value being provided.
+	Chain []string
+}
+
+// SpyProvider wraps an ioc.ProviderFunc, recording each call made to it. A
+// SpyProvider can also be configured to fail on a specific call via FailOn,
+// to exercise error handling in consumers of the provided value. A
+// SpyProvider is bound by passing its Provide method to ioc.Bind or similar:
+//
+//	spy := ioctest.NewSpyProvider(newClient)
+//	ioc.Bind(c, ioc.Singleton(spy.Provide))
+//
+// The zero value is not usable; construct one with NewSpyProvider. A
+// SpyProvider is safe for concurrent use.
+type SpyProvider[T any] struct {
+	provider ioc.ProviderFunc[T]
+
+	mu     sync.Mutex
+	calls  []Call
+	failOn int
+	err    error
+}
+
+// NewSpyProvider creates a SpyProvider wrapping fn.
+func NewSpyProvider[T any](fn ioc.ProviderFunc[T]) *SpyProvider[T] {
+	return &SpyProvider[T]{provider: fn}
+}
+
+// FailOn configures the SpyProvider to return err instead of calling the
+// wrapped provider on the nth call (starting from 1). Calls before and after
+// the nth call are unaffected. FailOn returns the SpyProvider to allow
+// chaining with NewSpyProvider.
+func (s *SpyProvider[T]) FailOn(n int, err error) *SpyProvider[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("ioctest: SpyProvider.FailOn call number must be positive, got %d", n))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failOn, s.err = n, err
+	return s
+}
+
+// Provide records the call and calls the wrapped provider, unless the
+// SpyProvider is configured to fail on this call via FailOn.
+func (s *SpyProvider[T]) Provide(c *ioc.Container) (value T, err error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{
+		Context: c.Context(),
+		Chain:   c.Chain(),
+	})
+	fail := len(s.calls) == s.failOn
+	err = s.err
+	s.mu.Unlock()
+
+	if fail {
+		return value, err
+	}
+
+	return s.provider(c)
+}
+
+// Count returns the number of calls made to the SpyProvider.
+func (s *SpyProvider[T]) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.calls)
+}
+
+// Calls returns the calls made to the SpyProvider, in order.
+func (s *SpyProvider[T]) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// Reset discards all calls recorded by the SpyProvider. The configuration from
+// FailOn is retained, and applies to calls counted after the reset.
+func (s *SpyProvider[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+var _ ioc.ProviderFunc[any] = (*SpyProvider[any])(nil).Provide
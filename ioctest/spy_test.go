@@ -0,0 +1,79 @@
+package ioctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rodaine/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spyKey struct{}
+
+func TestSpyProvider(t *testing.T) {
+	t.Parallel()
+
+	spy := NewSpyProvider(ioc.Static(123))
+
+	c := new(ioc.Container)
+	ioc.Bind(c, spy.Provide)
+	ioc.BindNamed(c, "foo", ioc.Infallible(func(c *ioc.Container) string {
+		return string(rune(ioc.Resolve[int](c)))
+	}))
+
+	assert.Zero(t, spy.Count())
+	assert.Equal(t, 123, ioc.Resolve[int](c))
+
+	ctx := context.WithValue(context.Background(), spyKey{}, "bar")
+	assert.Equal(t, "{", ioc.ResolveNamedContext[string](ctx, c, "foo"))
+
+	assert.Equal(t, 2, spy.Count())
+	calls := spy.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, []string{"int"}, calls[0].Chain)
+	assert.Nil(t, calls[0].Context.Value(spyKey{}))
+	assert.Equal(t, []string{"string:foo", "int"}, calls[1].Chain)
+	assert.Equal(t, "bar", calls[1].Context.Value(spyKey{}))
+
+	spy.Reset()
+	assert.Zero(t, spy.Count())
+	assert.Empty(t, spy.Calls())
+}
+
+func TestSpyProvider_Singleton(t *testing.T) {
+	t.Parallel()
+
+	spy := NewSpyProvider(ioc.Infallible(func(*ioc.Container) *cycleA { return &cycleA{} }))
+
+	c := new(ioc.Container)
+	ioc.Bind(c, ioc.Singleton(spy.Provide))
+
+	AssertSingleton[*cycleA](t, c)
+	assert.Equal(t, 1, spy.Count())
+}
+
+func TestSpyProvider_FailOn(t *testing.T) {
+	t.Parallel()
+
+	exErr := errors.New("oh no")
+	spy := NewSpyProvider(ioc.Static(123)).FailOn(2, exErr)
+
+	c := new(ioc.Container)
+	ioc.Bind(c, spy.Provide)
+
+	v, err := ioc.TryResolve[int](c)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, v)
+
+	_, err = ioc.TryResolve[int](c)
+	assert.ErrorIs(t, err, exErr)
+
+	v, err = ioc.TryResolve[int](c)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, v)
+	assert.Equal(t, 3, spy.Count())
+
+	assert.Panics(t, func() { spy.FailOn(0, exErr) })
+}
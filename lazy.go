@@ -0,0 +1,61 @@
+package ioc
+
+import (
+	"context"
+	"sync"
+)
+
+// Lazy defers resolving a value of type T until Get is first called. A Lazy[T]
+// can be resolved from any Container without an explicit binding; the name or
+// qualifier used to resolve the Lazy[T] is the one used to resolve T. Resolving a
+// Lazy[T] always succeeds, even if T is not (yet) resolvable. Any error is
+// instead returned by Get.
+//
+// Lazy is useful for breaking legitimate dependency cycles, or for deferring
+// the construction of an expensive value that may never be needed.
+type Lazy[T any] struct {
+	state *lazyState[T]
+}
+
+type lazyState[T any] struct {
+	once      sync.Once
+	ctx       context.Context
+	container *Container
+	name      typeName
+	value     T
+	err       error
+}
+
+func newLazy[T any](ctx context.Context, c *Container, name typeName) Lazy[T] {
+	return Lazy[T]{state: &lazyState[T]{
+		ctx:       ctx,
+		container: c.unwind(),
+		name:      name,
+	}}
+}
+
+// Get resolves T from the Container and context.Context that produced the
+// Lazy on its first call. Subsequent calls return the same value and error. To
+// avoid unbounded recursion, Get should not be called from within the
+// ProviderFunc that resolved the Lazy if T in turn depends on that provider.
+func (l Lazy[T]) Get() (T, error) {
+	if l.state == nil {
+		var zero T
+		return zero, MissingProviderError{name: newTypeName[T](anonymous)}
+	}
+
+	l.state.once.Do(func() {
+		l.state.value, l.state.err = tryResolve[T](
+			l.state.ctx, l.state.container, l.state.name)
+	})
+
+	return l.state.value, l.state.err
+}
+
+func (Lazy[T]) implicitProvider(tn typeName) providerFunc {
+	return func(c *Container) (any, error) {
+		return newLazy[T](c.Context(), c, retype[T](tn)), nil
+	}
+}
+
+var _ implicitBinding = Lazy[any]{}
@@ -0,0 +1,121 @@
+package ioc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lazyA struct{ b Lazy[*lazyB] }
+
+type lazyB struct{ a *lazyA }
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deferred", func(t *testing.T) {
+		t.Parallel()
+
+		counter := 0
+		c := new(Container)
+		Bind(c, Infallible(func(*Container) int {
+			counter++
+			return counter
+		}))
+
+		lazy, err := TryResolve[Lazy[int]](c)
+		require.NoError(t, err)
+		assert.Zero(t, counter)
+
+		v, err := lazy.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+
+		v, err = lazy.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v, "should cache the resolved value")
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("named", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "foo", Static("bar"))
+
+		v, err := ResolveNamed[Lazy[string]](c, "foo").Get()
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", v)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		lazy, err := TryResolve[Lazy[int]](c)
+		require.NoError(t, err)
+
+		v, err := lazy.Get()
+		assert.Zero(t, v)
+		assert.ErrorAs(t, err, &MissingProviderError{})
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Lazy[int]{}.Get()
+		assert.Zero(t, v)
+		assert.ErrorAs(t, err, &MissingProviderError{})
+	})
+
+	t.Run("context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "foo", "bar")
+
+		c := new(Container)
+		var outFoo any
+		Bind(c, Infallible(func(c *Container) int {
+			outFoo = c.Context().Value("foo")
+			return 123
+		}))
+
+		_, err := ResolveContext[Lazy[int]](ctx, c).Get()
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", outFoo)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		Bind(c, Singleton(func(c *Container) (*lazyA, error) {
+			b, err := TryResolve[Lazy[*lazyB]](c)
+			return &lazyA{b: b}, err
+		}))
+		Bind(c, Singleton(func(c *Container) (*lazyB, error) {
+			a, err := TryResolve[*lazyA](c)
+			return &lazyB{a: a}, err
+		}))
+
+		a, err := TryResolve[*lazyA](c)
+		require.NoError(t, err)
+
+		b, err := a.b.Get()
+		require.NoError(t, err)
+		assert.Same(t, a, b.a)
+	})
+
+	t.Run("explicit binding", func(t *testing.T) {
+		t.Parallel()
+
+		lazy := newLazy[int](context.Background(), new(Container), newTypeName[int](anonymous))
+
+		c := new(Container)
+		Bind(c, Static(lazy))
+		assert.Equal(t, lazy, Resolve[Lazy[int]](c),
+			"explicit bindings should take precedence")
+	})
+}
@@ -0,0 +1,103 @@
+package ioc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Module is a reusable, named bundle of bindings. Packages can export a Module
+// that configures all of their providers, which consuming code then adds to a
+// Container via Install.
+type Module struct {
+	// Name uniquely identifies the Module. Modules are de-duplicated by name,
+	// so two Modules with the same name are considered the same Module.
+	Name string
+	// Requires lists the Modules that must be installed before this one.
+	Requires []*Module
+	// Configure binds the Module's providers to the Container passed to it,
+	// which adds them to the Container the Module is installed on and records
+	// the Module as their source. Configure must not call Install, which
+	// panics; list other Modules in Requires instead.
+	Configure func(c *Container)
+}
+
+// Install adds the bindings from each of the modules (and the modules they
+// require) to the Container. Required modules are configured before the
+// modules that depend on them, and each Module is installed at most once
+// across the Container and its ancestors in the Extend chain. The Module that
+// contributed each binding is reported by Container.Bindings.
+//
+// Install panics if the Container is frozen, if a Module has no name, if the
+// modules' requirements form a cycle, or if it is called with the Container
+// passed to a Module's Configure function.
+func Install(c *Container, modules ...*Module) {
+	if c.module != nil {
+		panic(fmt.Sprintf("ioc.Install: called while configuring module %s; list required modules in Requires instead", c.module.Name))
+	}
+
+	c.checkFrozen()
+	c.installMu.Lock()
+	defer c.installMu.Unlock()
+
+	var path []string
+	visiting := map[string]bool{}
+
+	var visit func(m *Module)
+	visit = func(m *Module) {
+		if m.Name == "" {
+			panic("ioc.Install: module has no name")
+		}
+
+		path = append(path, m.Name)
+		defer func() { path = path[:len(path)-1] }()
+
+		if visiting[m.Name] {
+			panic(fmt.Sprintf("ioc.Install: module dependency cycle: %s", strings.Join(path, " -> ")))
+		}
+		if c.installed(m.Name) {
+			return
+		}
+
+		visiting[m.Name] = true
+		for _, req := range m.Requires {
+			visit(req)
+		}
+		visiting[m.Name] = false
+
+		if m.Configure != nil {
+			m.Configure(&Container{parent: c, ctx: c.ctx, module: m})
+		}
+		c.modules.Store(m.Name, m)
+	}
+
+	for _, m := range modules {
+		visit(m)
+	}
+}
+
+func (c *Container) installed(name string) bool {
+	for _, rc := range c.levels() {
+		if _, ok := rc.modules.Load(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// target returns the Container modified by bindings and other configuration
+// made via c: the Container a Module is being installed on if c was passed to
+// the Module's Configure function, or else c itself.
+func (c *Container) target() *Container {
+	if c.module != nil {
+		return c.parent
+	}
+	return c
+}
+
+// attribute records the Module being configured via c, if any, as the source
+// of reg.
+func (c *Container) attribute(reg *registration) {
+	if c.module != nil {
+		reg.module = c.module.Name
+	}
+}
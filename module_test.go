@@ -0,0 +1,180 @@
+package ioc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	base := &Module{
+		Name: "base",
+		Configure: func(c *Container) {
+			order = append(order, "base")
+			Bind(c, Static(123))
+		},
+	}
+	foo := &Module{
+		Name:     "foo",
+		Requires: []*Module{base},
+		Configure: func(c *Container) {
+			order = append(order, "foo")
+			BindNamed(c, "foo", Static("foo"))
+		},
+	}
+	bar := &Module{
+		Name:     "bar",
+		Requires: []*Module{base, foo},
+		Configure: func(c *Container) {
+			order = append(order, "bar")
+			BindDefaultNamed(c, "bar", Static("bar"))
+		},
+	}
+
+	c := new(Container)
+	c.Strict()
+	Bind(c, Static(true))
+	Install(c, bar, foo)
+	Install(c, base)
+
+	assert.Equal(t, []string{"base", "foo", "bar"}, order)
+	assert.Equal(t, 123, Resolve[int](c))
+	assert.Equal(t, "foo", ResolveNamed[string](c, "foo"))
+	assert.Equal(t, "bar", ResolveNamed[string](c, "bar"))
+
+	modules := map[string]string{}
+	for _, b := range c.Bindings() {
+		modules[b.typeName().String()] = b.Module
+	}
+	assert.Equal(t, map[string]string{
+		"bool":       "",
+		"int":        "base",
+		"string:foo": "foo",
+		"string:bar": "bar",
+	}, modules)
+
+	child := c.Extend()
+	Install(child, foo)
+	assert.Equal(t, []string{"base", "foo", "bar"}, order,
+		"should not reinstall modules installed on a parent")
+}
+
+func TestInstall_Panics(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "ioc.Install: module has no name", func() {
+		Install(new(Container), &Module{})
+	})
+
+	a := &Module{Name: "a"}
+	b := &Module{Name: "b", Requires: []*Module{a}}
+	a.Requires = []*Module{b}
+
+	assert.PanicsWithValue(t, "ioc.Install: module dependency cycle: a -> b -> a", func() {
+		Install(new(Container), a)
+	})
+
+	c := new(Container)
+	c.Freeze()
+	assert.Panics(t, func() { Install(c, &Module{Name: "c"}) })
+
+	nested := &Module{
+		Name:      "outer",
+		Configure: func(c *Container) { Install(c, &Module{Name: "inner"}) },
+	}
+	c = new(Container)
+	assert.PanicsWithValue(t, "ioc.Install: called while configuring module outer; list required modules in Requires instead", func() {
+		Install(c, nested)
+	})
+	assert.NotPanics(t, func() { Install(c, &Module{Name: "inner"}) }, "should remain usable")
+}
+
+func TestInstall_Rebind(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(123))
+	Install(c, &Module{
+		Name:      "override",
+		Configure: func(c *Container) { Rebind(c, Static(456)) },
+	})
+
+	bindings := c.Bindings()
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "override", bindings[0].Module)
+	assert.Equal(t, 456, Resolve[int](c))
+}
+
+func TestInstall_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	configuring, release := make(chan struct{}), make(chan struct{})
+	slow := &Module{
+		Name: "slow",
+		Configure: func(c *Container) {
+			close(configuring)
+			<-release
+			Bind(c, Static(123))
+		},
+	}
+	fast := &Module{
+		Name:      "fast",
+		Configure: func(c *Container) { BindNamed(c, "fast", Static("fast")) },
+	}
+
+	c := new(Container)
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		assert.NotPanics(t, func() { Install(c, slow) })
+	}()
+	go func() {
+		defer wg.Done()
+		<-configuring
+		Bind(c, Static("unrelated"))
+		go func() {
+			defer wg.Done()
+			assert.NotPanics(t, func() { Install(c, fast) }, "should wait for the other Install")
+		}()
+		close(release)
+	}()
+	wg.Wait()
+
+	modules := map[string]string{}
+	for _, b := range c.Bindings() {
+		modules[b.typeName().String()] = b.Module
+	}
+	assert.Equal(t, map[string]string{
+		"int":         "slow",
+		"string":      "",
+		"string:fast": "fast",
+	}, modules, "should only attribute bindings made via Configure")
+}
+
+func TestInstall_Configure(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Install(c, &Module{
+		Name: "mod",
+		Configure: func(c *Container) {
+			c.Strict()
+			c.ActivateProfiles("dev")
+			Bind(c, Static(123))
+
+			bindings := c.Bindings()
+			require.Len(t, bindings, 1)
+			assert.Zero(t, bindings[0].Depth)
+		},
+	})
+
+	assert.True(t, c.enabled(optStrict), "should configure the Container installed on")
+	assert.Equal(t, []string{"dev"}, c.Profiles())
+	assert.Panics(t, func() { Bind(c, Static(456)) })
+}
@@ -0,0 +1,106 @@
+package ioc
+
+import (
+	"context"
+	"errors"
+)
+
+// Optional is the result of resolving a value of type T that may not be bound
+// to the Container. An Optional[T] can be resolved from any Container without
+// an explicit binding; the name or qualifier used to resolve the Optional[T]
+// is the one used to resolve T.
+//
+// If no provider exists for T, Found is false and no error is returned. Other
+// errors finding the provider of T (such as an AmbiguousProviderError) and
+// errors from the provider itself (including its own missing dependencies) are
+// still returned when resolving the Optional.
+type Optional[T any] struct {
+	Value T
+	Found bool
+}
+
+func (Optional[T]) implicitProvider(tn typeName) providerFunc {
+	return func(c *Container) (any, error) {
+		v, found, err := resolveOptional[T](c.Context(), c, retype[T](tn))
+		return Optional[T]{Value: v, Found: found}, err
+	}
+}
+
+// resolveOptional resolves a value for target, reporting whether a provider is
+// bound for it. Only a MissingProviderError for target itself results in found
+// being false with a nil error; all other errors are returned.
+func resolveOptional[T any](ctx context.Context, c *Container, target typeName) (value T, found bool, err error) {
+	reg, err := findProvider[T](c, target)
+	switch {
+	case errors.As(err, &MissingProviderError{}):
+		return value, false, nil
+	case err != nil:
+		return value, false, err
+	}
+
+	c.deps.add(target)
+	v, err := c.resolve(ctx, target, reg, c.graphing)
+	if err != nil {
+		return value, false, err
+	}
+
+	return v.(T), true, nil
+}
+
+// TryResolveNamedOptionalContext will attempt to resolve a value for type T
+// with the specified name, if a provider is bound for it. The provided
+// context.Context will be passed to the target ProviderFunc via the
+// Resolver.Context method.
+//
+// If no provider can be found for the specified type and name, found is false
+// and err is nil. An error is returned if the provider is ambiguous
+// (AmbiguousProviderError), if there is a dependency cycle in resolving
+// (CircularDependencyError), or if the provider returns an error. If an
+// Optional[T] is explicitly bound with the specified name, it is resolved
+// instead.
+func TryResolveNamedOptionalContext[T any](ctx context.Context, c *Container, name string) (value T, found bool, err error) {
+	// an explicitly bound Optional[T] takes precedence, as it does when
+	// resolving the Optional[T] itself
+	oname := newTypeName[Optional[T]](name)
+	if _, err = c.lookup(oname); err == nil {
+		opt, err := tryResolve[Optional[T]](ctx, c, oname)
+		return opt.Value, opt.Found && err == nil, err
+	}
+
+	// otherwise, resolve T directly rather than via Optional[T], so that errors
+	// do not include an Optional[T] the caller did not request in the chain
+	return resolveOptional[T](ctx, c, newTypeName[T](name))
+}
+
+// TryResolveOptionalContext will attempt to resolve a value for type T, if a
+// provider is bound for it. The provided context.Context will be passed to the
+// target ProviderFunc via the Resolver.Context method.
+//
+// If no provider can be found for the specified type, found is false and err
+// is nil. An error is returned if there is a dependency cycle in resolving
+// (CircularDependencyError), or if the provider returns an error.
+func TryResolveOptionalContext[T any](ctx context.Context, c *Container) (value T, found bool, err error) {
+	return TryResolveNamedOptionalContext[T](ctx, c, anonymous)
+}
+
+// TryResolveNamedOptional will attempt to resolve a value for type T with the
+// specified name, if a provider is bound for it.
+//
+// If no provider can be found for the specified type and name, found is false
+// and err is nil. An error is returned if there is a dependency cycle in
+// resolving (CircularDependencyError), or if the provider returns an error.
+func TryResolveNamedOptional[T any](c *Container, name string) (value T, found bool, err error) {
+	return TryResolveNamedOptionalContext[T](c.ctx, c, name)
+}
+
+// TryResolveOptional will attempt to resolve a value for type T, if a provider
+// is bound for it.
+//
+// If no provider can be found for the specified type, found is false and err
+// is nil. An error is returned if there is a dependency cycle in resolving
+// (CircularDependencyError), or if the provider returns an error.
+func TryResolveOptional[T any](c *Container) (value T, found bool, err error) {
+	return TryResolveOptionalContext[T](c.ctx, c)
+}
+
+var _ implicitBinding = Optional[any]{}
@@ -0,0 +1,172 @@
+package ioc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindNamed(c, "foo", Static(123))
+
+	assert.Equal(t, Optional[int]{Value: 123, Found: true}, ResolveNamed[Optional[int]](c, "foo"))
+	assert.Equal(t, Optional[int]{}, ResolveNamed[Optional[int]](c, "bar"))
+}
+
+func TestTryResolveNamedOptionalContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "foo", "bar")
+
+		c := new(Container)
+		var outFoo any
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			outFoo = c.Context().Value("foo")
+			return 123, nil
+		})
+
+		out, found, err := TryResolveNamedOptionalContext[int](ctx, c, "fizz")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 123, out)
+		assert.Equal(t, "bar", outFoo)
+	})
+
+	t.Run("missing provider", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+
+		out, found, err := TryResolveNamedOptionalContext[int](context.Background(), c, "fizz")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Zero(t, out)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		exErr := errors.New("some error")
+		BindNamed(c, "fizz", func(_ *Container) (int, error) {
+			return 42, exErr
+		})
+
+		out, found, err := TryResolveNamedOptionalContext[int](context.Background(), c, "fizz")
+		assert.ErrorIs(t, err, exErr)
+		assert.NotContains(t, err.Error(), "Optional", "should not include the implicit Optional in the chain")
+		assert.False(t, found)
+		assert.Zero(t, out)
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			return TryResolveNamed[int](c, "buzz")
+		})
+
+		_, found, err := TryResolveNamedOptionalContext[int](context.Background(), c, "fizz")
+		assert.ErrorAs(t, err, &MissingProviderError{})
+		assert.False(t, found)
+	})
+}
+
+func TestTryResolveNamedOptionalContext_Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	c.MatchAssignable()
+	Bind(c, Static(&bytes.Buffer{}))
+	Bind(c, Static(&strings.Builder{}))
+
+	_, found, err := TryResolveOptional[io.Writer](c)
+	assert.ErrorAs(t, err, &AmbiguousProviderError{})
+	assert.False(t, found)
+
+	opt, err := TryResolve[Optional[io.Writer]](c)
+	assert.ErrorAs(t, err, &AmbiguousProviderError{})
+	assert.False(t, opt.Found)
+}
+
+func TestTryResolveNamedOptionalContext_Explicit(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindNamed(c, "foo", Static(123))
+	BindNamed(c, "foo", Static(Optional[int]{Value: 456, Found: true}))
+	BindNamed(c, "bar", Static(Optional[int]{}))
+
+	v, found, err := TryResolveNamedOptional[int](c, "foo")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 456, v, "should prefer the bound Optional")
+
+	_, found, err = TryResolveNamedOptional[int](c, "bar")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestTryResolveOptional(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Static(42))
+
+	out, found, err := TryResolveOptional[int](c)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 42, out)
+
+	_, found, err = TryResolveOptional[string](c)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestTryResolveNamedOptional(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindNamed(c, "foo", Static(42))
+
+	out, found, err := TryResolveNamedOptional[int](c, "foo")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 42, out)
+
+	_, found, err = TryResolveNamedOptional[int](c, "bar")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestTryResolveOptionalContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), "foo", "bar")
+
+	c := new(Container)
+	var outFoo any
+	Bind(c, Infallible(func(c *Container) int {
+		outFoo = c.Context().Value("foo")
+		return 42
+	}))
+
+	out, found, err := TryResolveOptionalContext[int](ctx, c)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 42, out)
+	assert.Equal(t, "bar", outFoo)
+}
@@ -1,6 +1,10 @@
 package ioc
 
-import "sync"
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
 
 type providerFunc func(*Container) (any, error)
 
@@ -19,29 +23,70 @@ func (fn ProviderFunc[T]) provide(c *Container) (any, error) { return fn(c) }
 // Singleton wraps fn, returning a new ProviderFunc with the same signature,
 // but the returned values always remain the same. Singleton is useful for
 // defining a provider to a value that should be shared, such as
-// network/database clients, loggers, or other thread-safe utilities.
+// network/database clients, loggers, or other thread-safe utilities. If the
+// wrapped provider panics, the panic is propagated and all subsequent calls
+// return an error.
 func Singleton[T any](provider ProviderFunc[T]) ProviderFunc[T] {
 	var value T
 	var err error
 	once := &sync.Once{}
 
-	return func(c *Container) (T, error) {
-		once.Do(func() { value, err = provider(c) })
+	return kinded("singleton", func(c *Container) (T, error) {
+		once.Do(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					if rErr, ok := r.(error); ok {
+						err = fmt.Errorf("singleton provider panicked: %w", rErr)
+					} else {
+						err = fmt.Errorf("singleton provider panicked: %v", r)
+					}
+					panic(r)
+				}
+			}()
+			value, err = provider(c)
+		})
 		return value, err
-	}
+	})
 }
 
 // Static creates a ProviderFunc that always returns (v, nil). Static is useful
 // where the value does not have other dependencies that need to be resolved
 // before consumption.
 func Static[T any](v T) ProviderFunc[T] {
-	return func(*Container) (T, error) { return v, nil }
+	return kinded("static", func(*Container) (T, error) { return v, nil })
 }
 
 // Infallible is a helper that converts a func(c Resolver) T to a ProviderFunc
 // where an error is never returned.
 func Infallible[T any](fn func(c *Container) T) ProviderFunc[T] {
-	return func(c *Container) (T, error) { return fn(c), nil }
+	return kinded("infallible", func(c *Container) (T, error) { return fn(c), nil })
+}
+
+// kinds maps the code pointer of each ProviderFunc created by Singleton,
+// Static, or Infallible to the name of that helper. Since the pointer
+// identifies the function literal rather than each closure, the map holds at
+// most one entry per literal.
+var kinds syncMap[uintptr, string]
+
+// kinded records fn as created by the helper named kind, returning fn.
+func kinded[T any](kind string, fn ProviderFunc[T]) ProviderFunc[T] {
+	pc := reflect.ValueOf(fn).Pointer()
+	if _, ok := kinds.Load(pc); !ok {
+		kinds.Store(pc, kind)
+	}
+	return fn
+}
+
+// providerKind describes fn by the helper that created it, as recorded by
+// kinded: "singleton", "static", or "infallible". Any other ProviderFunc is a
+// "provider".
+func providerKind[T any](fn ProviderFunc[T]) string {
+	if fn != nil {
+		if kind, ok := kinds.Load(reflect.ValueOf(fn).Pointer()); ok {
+			return kind
+		}
+	}
+	return "provider"
 }
 
 var _ providerFunc = (ProviderFunc[any])(nil).provide
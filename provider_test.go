@@ -1,6 +1,7 @@
 package ioc
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,32 @@ func TestSingleton(t *testing.T) {
 	assert.Equal(t, 4, counter)
 }
 
+func TestSingleton_Panic(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	Bind(c, Singleton(func(*Container) (int, error) {
+		panic("oh no")
+	}))
+
+	_, err := TryResolve[int](c)
+	assert.ErrorAs(t, err, &ProviderPanicError{})
+
+	_, err = TryResolve[int](c)
+	assert.ErrorContains(t, err, "singleton provider panicked: oh no")
+
+	exErr := errors.New("some error")
+	Rebind(c, Singleton(func(*Container) (int, error) {
+		panic(exErr)
+	}))
+
+	_, err = TryResolve[int](c)
+	assert.ErrorAs(t, err, &ProviderPanicError{})
+
+	_, err = TryResolve[int](c)
+	assert.ErrorIs(t, err, exErr, "should wrap a panicked error")
+}
+
 func TestStatic(t *testing.T) {
 	t.Parallel()
 
@@ -53,3 +80,27 @@ func TestInfallible(t *testing.T) {
 	assert.Equal(t, "foobar", Resolve[string](c))
 	assert.Equal(t, "foobar", Resolve[string](c))
 }
+
+func TestProviderKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider ProviderFunc[int]
+		expected string
+	}{
+		{"static", Static(123), "static"},
+		{"singleton", Singleton(Static(123)), "singleton"},
+		{"infallible", Infallible(func(*Container) int { return 123 }), "infallible"},
+		{"func", func(*Container) (int, error) { return 123, nil }, "provider"},
+		{"nil", nil, "provider"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, providerKind(test.provider))
+		})
+	}
+}
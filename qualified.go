@@ -0,0 +1,66 @@
+package ioc
+
+import "context"
+
+// BindQualified associates a ProviderFunc with type T, qualified by the marker
+// type Q. Qualifiers are an alternative to BindNamed that are checked by the
+// compiler, avoiding typos in free-form names. Q is typically an empty struct
+// type declared for this purpose:
+//
+//	type Primary struct{}
+//
+//	ioc.BindQualified[*sql.DB, Primary](c, primaryDB)
+//	db := ioc.ResolveQualified[*sql.DB, Primary](c)
+//
+// A qualified binding is distinct from both anonymous and named bindings of the
+// same type.
+func BindQualified[T, Q any](c *Container, fn ProviderFunc[T]) {
+	c.bind(newQualifiedTypeName[T, Q](), newRegistration(fn, 0), false)
+}
+
+// HasQualified reports whether a provider can be found for type T with the
+// qualifier Q, without resolving it.
+func HasQualified[T, Q any](c *Container) bool {
+	_, err := findProvider[T](c, newQualifiedTypeName[T, Q]())
+	return err == nil
+}
+
+// TryResolveQualifiedContext will attempt to resolve a value for type T with
+// the qualifier Q. The provided context.Context will be passed to the target
+// ProviderFunc via the Resolver.Context method.
+//
+// An error is returned if a provider cannot be found for the specified type
+// and qualifier (MissingProviderError), if there is dependency cycle in
+// resolving (CircularDependencyError), if the provider returns an error
+// (ResolveError), or if the provider panics (ProviderPanicError).
+func TryResolveQualifiedContext[T, Q any](ctx context.Context, c *Container) (T, error) {
+	return tryResolve[T](ctx, c, newQualifiedTypeName[T, Q]())
+}
+
+// TryResolveQualified will attempt to resolve a value for type T with the
+// qualifier Q.
+//
+// An error is returned if a provider cannot be found for the specified type
+// and qualifier (MissingProviderError), if there is dependency cycle in
+// resolving (CircularDependencyError), if the provider returns an error
+// (ResolveError), or if the provider panics (ProviderPanicError).
+func TryResolveQualified[T, Q any](c *Container) (T, error) {
+	return TryResolveQualifiedContext[T, Q](c.ctx, c)
+}
+
+// ResolveQualifiedContext behaves like TryResolveQualifiedContext, but panics
+// in the event of an error resolving a value.
+func ResolveQualifiedContext[T, Q any](ctx context.Context, c *Container) T {
+	value, err := TryResolveQualifiedContext[T, Q](ctx, c)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// ResolveQualified behaves like TryResolveQualified, but panics in the event of
+// an error resolving a value.
+func ResolveQualified[T, Q any](c *Container) T {
+	return ResolveQualifiedContext[T, Q](c.ctx, c)
+}
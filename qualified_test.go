@@ -0,0 +1,92 @@
+package ioc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	primary   struct{}
+	secondary struct{}
+)
+
+func TestBindQualified(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindQualified[int, primary](c, Static(123))
+	BindQualified[int, secondary](c, Static(456))
+	Bind(c, Static(789))
+
+	assert.Equal(t, 123, ResolveQualified[int, primary](c))
+	assert.Equal(t, 456, ResolveQualified[int, secondary](c))
+	assert.Equal(t, 789, Resolve[int](c))
+
+	assert.True(t, HasQualified[int, primary](c))
+	assert.False(t, HasQualified[string, primary](c))
+
+	c.Freeze()
+	assert.Panics(t, func() { BindQualified[int, primary](c, Static(0)) })
+}
+
+func TestBindQualified_Implicit(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindQualified[string, primary](c, Static("foo"))
+
+	v, err := ResolveQualified[Lazy[string], primary](c).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", v)
+
+	v, err = ResolveQualified[Factory[string], primary](c)(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", v)
+
+	opt := ResolveQualified[Optional[string], secondary](c)
+	assert.False(t, opt.Found)
+}
+
+func TestTryResolveQualifiedContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), "foo", "bar")
+
+	c := new(Container)
+	var outFoo any
+	BindQualified[int, primary](c, func(c *Container) (int, error) {
+		outFoo = c.Context().Value("foo")
+		return 123, nil
+	})
+
+	out, err := TryResolveQualifiedContext[int, primary](ctx, c)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, out)
+	assert.Equal(t, "bar", outFoo)
+
+	_, err = TryResolveQualifiedContext[int, secondary](ctx, c)
+	assert.ErrorAs(t, err, &MissingProviderError{})
+}
+
+func TestTryResolveQualified(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindQualified[int, primary](c, Static(42))
+
+	out, err := TryResolveQualified[int, primary](c)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, out)
+}
+
+func TestResolveQualifiedContext(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindQualified[int, primary](c, Static(42))
+
+	assert.Equal(t, 42, ResolveQualifiedContext[int, primary](context.Background(), c))
+	assert.Panics(t, func() { ResolveQualifiedContext[int, secondary](context.Background(), c) })
+}
@@ -0,0 +1,117 @@
+package ioc
+
+import "sync"
+
+// Reloadable holds a value of type T that can be swapped at runtime, such as
+// configuration or feature flags. Dependents can either call Load each time
+// they need the current value, or Subscribe to be notified of changes so they
+// can rebuild any state derived from it. A Reloadable is bound to a Container
+// via BindReloadable or BindReloadableNamed.
+//
+// The Reloadable type is thread-safe.
+type Reloadable[T any] struct {
+	_        noCopy
+	storeMu  sync.Mutex
+	mu       sync.RWMutex
+	value    T
+	validate func(T) error
+	subs     []*subscription[T]
+}
+
+type subscription[T any] struct {
+	fn func(T)
+}
+
+// NewReloadable creates a Reloadable with the initial value. If validate is
+// non-nil, it is called with each value passed to Store, and the value is
+// rejected if it returns an error. The initial value is not validated.
+func NewReloadable[T any](initial T, validate func(T) error) *Reloadable[T] {
+	return &Reloadable[T]{
+		value:    initial,
+		validate: validate,
+	}
+}
+
+// Load returns the current value.
+func (r *Reloadable[T]) Load() T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.value
+}
+
+// Store validates v and, if it is valid, replaces the current value and
+// notifies all subscribers in the order they subscribed. If v is invalid, the
+// validation error is returned and the current value is retained. Calls to
+// Store are serialized, so subscribers observe changes in order; subscribers
+// must not call Store themselves.
+func (r *Reloadable[T]) Store(v T) error {
+	r.storeMu.Lock()
+	defer r.storeMu.Unlock()
+
+	if r.validate != nil {
+		if err := r.validate(v); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.value = v
+	subs := append([]*subscription[T](nil), r.subs...)
+	r.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.fn(v)
+	}
+
+	return nil
+}
+
+// Subscribe registers fn to be called with each new value passed to Store. The
+// returned function cancels the subscription; it is safe to call more than
+// once.
+func (r *Reloadable[T]) Subscribe(fn func(T)) (cancel func()) {
+	sub := &subscription[T]{fn: fn}
+
+	r.mu.Lock()
+	r.subs = append(r.subs, sub)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		for i, s := range r.subs {
+			if s == sub {
+				r.subs = append(r.subs[:i:i], r.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func bindReloadable[T any](c *Container, name string, r *Reloadable[T], source string) {
+	c.bind(newTypeName[*Reloadable[T]](name), registration{
+		provider: Static(r).provide,
+		source:   source,
+		kind:     "reloadable",
+	}, false)
+	c.bind(newTypeName[T](name), registration{
+		provider: Infallible(func(*Container) T { return r.Load() }).provide,
+		source:   source,
+		kind:     "reloadable",
+	}, false)
+}
+
+// BindReloadableNamed binds r with the specified name as both a
+// *Reloadable[T] and a T. Resolving the T returns the value current at the
+// time of resolution, so providers that need to observe changes should depend
+// on the *Reloadable[T] instead.
+func BindReloadableNamed[T any](c *Container, name string, r *Reloadable[T]) {
+	bindReloadable(c, name, r, callerSource(0))
+}
+
+// BindReloadable binds r anonymously as both a *Reloadable[T] and a T. It is
+// equivalent to calling BindReloadableNamed with an empty name argument.
+func BindReloadable[T any](c *Container, r *Reloadable[T]) {
+	bindReloadable(c, anonymous, r, callerSource(0))
+}
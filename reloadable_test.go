@@ -0,0 +1,108 @@
+package ioc
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadable(t *testing.T) {
+	t.Parallel()
+
+	exErr := errors.New("must be positive")
+	r := NewReloadable(1, func(v int) error {
+		if v <= 0 {
+			return exErr
+		}
+		return nil
+	})
+	assert.Equal(t, 1, r.Load())
+
+	var a, b []int
+	cancelA := r.Subscribe(func(v int) { a = append(a, v) })
+	r.Subscribe(func(v int) { b = append(b, v) })
+
+	require.NoError(t, r.Store(2))
+	assert.ErrorIs(t, r.Store(-1), exErr)
+	assert.Equal(t, 2, r.Load(), "should retain the last valid value")
+
+	cancelA()
+	cancelA()
+	require.NoError(t, r.Store(3))
+
+	assert.Equal(t, []int{2}, a)
+	assert.Equal(t, []int{2, 3}, b)
+	assert.Equal(t, 3, r.Load())
+}
+
+func TestReloadable_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	// validate and the subscriber are only called within Store, so if Store
+	// serializes its callers they need no synchronization of their own and
+	// observe the values in the same order
+	var validated, observed []int
+	r := NewReloadable(0, func(v int) error {
+		validated = append(validated, v)
+		return nil
+	})
+
+	var inFlight atomic.Int32
+	r.Subscribe(func(v int) {
+		assert.Equal(t, int32(1), inFlight.Add(1), "should not notify concurrently")
+		assert.Equal(t, v, r.Load(), "should not change the value while notifying")
+		observed = append(observed, v)
+		inFlight.Add(-1)
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			assert.NoError(t, r.Store(v))
+			_ = r.Load()
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, observed, 100)
+	assert.Equal(t, validated, observed, "should notify in the order values were stored")
+	assert.Equal(t, observed[len(observed)-1], r.Load())
+}
+
+func TestBindReloadable(t *testing.T) {
+	t.Parallel()
+
+	r := NewReloadable("foo", nil)
+
+	c := new(Container)
+	BindReloadable(c, r)
+
+	assert.Same(t, r, Resolve[*Reloadable[string]](c))
+	assert.Equal(t, "foo", Resolve[string](c))
+
+	require.NoError(t, r.Store("bar"))
+	assert.Equal(t, "bar", Resolve[string](c))
+}
+
+func TestBindReloadableNamed(t *testing.T) {
+	t.Parallel()
+
+	r := NewReloadable(123, nil)
+
+	c := new(Container)
+	BindReloadableNamed(c, "foo", r)
+
+	assert.Same(t, r, ResolveNamed[*Reloadable[int]](c, "foo"))
+	assert.Equal(t, 123, ResolveNamed[int](c, "foo"))
+	assert.False(t, Has[int](c))
+
+	for _, b := range c.Bindings() {
+		assert.Contains(t, b.Source, "reloadable_test.go:")
+	}
+}
@@ -1,6 +1,36 @@
 package ioc
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// implicitBinding is implemented by types that a Container can provide without
+// an explicit binding, such as Lazy.
+type implicitBinding interface {
+	implicitProvider(tn typeName) providerFunc
+}
+
+// findProvider looks up the ProviderFunc bound to tn, falling back to the
+// implicit provider for T if one exists. Explicit bindings always take
+// precedence over implicit ones.
+func findProvider[T any](c *Container, tn typeName) (registration, error) {
+	reg, err := c.lookup(tn)
+	if err == nil {
+		return reg, nil
+	}
+
+	var zero T
+	if ib, ok := any(zero).(implicitBinding); ok {
+		return registration{
+			provider: ib.implicitProvider(tn),
+			source:   "implicit",
+			kind:     "implicit",
+		}, nil
+	}
+
+	return registration{}, err
+}
 
 // TryResolveNamedContext will attempt to resolve a value for type T with the
 // specified name. The provided context.Context will be passed to the target
@@ -8,26 +38,46 @@ import "context"
 //
 // An error is returned if a provider cannot be found for the specified type
 // and name (MissingProviderError), if there is dependency cycle in resolving
-// (CircularDependencyError), or if the provider returns an error.
+// (CircularDependencyError), if the provider returns an error (ResolveError),
+// or if the provider panics (ProviderPanicError).
 func TryResolveNamedContext[T any](ctx context.Context, container *Container, name string) (value T, err error) {
-	tname := newTypeName[T](name)
+	return tryResolve[T](ctx, container, newTypeName[T](name))
+}
+
+func tryResolve[T any](ctx context.Context, container *Container, tname typeName) (value T, err error) {
+	container.deps.add(tname)
 
-	provider, err := container.lookup(tname)
+	reg, err := findProvider[T](container, tname)
 	if err != nil {
+		var mpErr MissingProviderError
+		if errors.As(err, &mpErr) {
+			err = container.missingProvider(mpErr.name)
+		}
 		return value, err
 	}
 
-	container, err = container.startResolving(ctx, tname)
+	v, err := container.resolve(ctx, tname, reg, container.graphing)
 	if err != nil {
 		return value, err
 	}
 
-	v, err := provider(container)
+	return v.(T), nil
+}
+
+// resolve produces a value for tname from the registration found for it,
+// recording the dependencies of the providers called if graphing is true.
+func (c *Container) resolve(ctx context.Context, tname typeName, reg registration, graphing bool) (any, error) {
+	resolver, err := c.startResolving(ctx, tname, reg, graphing)
 	if err != nil {
-		return value, err
+		return nil, err
 	}
 
-	return v.(T), nil
+	v, err := c.provide(resolver, tname, reg.provider)
+	if err != nil {
+		return nil, c.resolveError(tname, err)
+	}
+
+	return v, nil
 }
 
 // TryResolveContext will attempt to resolve a value for type T. The provided
@@ -36,7 +86,8 @@ func TryResolveNamedContext[T any](ctx context.Context, container *Container, na
 //
 // An error is returned if a provider cannot be found for the specified type
 // and name (MissingProviderError), if there is dependency cycle in resolving
-// (CircularDependencyError), or if the provider returns an error.
+// (CircularDependencyError), if the provider returns an error (ResolveError),
+// or if the provider panics (ProviderPanicError).
 func TryResolveContext[T any](ctx context.Context, c *Container) (value T, err error) {
 	return TryResolveNamedContext[T](ctx, c, anonymous)
 }
@@ -46,7 +97,8 @@ func TryResolveContext[T any](ctx context.Context, c *Container) (value T, err e
 //
 // An error is returned if a provider cannot be found for the specified type
 // and name (MissingProviderError), if there is dependency cycle in resolving
-// (CircularDependencyError), or if the provider returns an error.
+// (CircularDependencyError), if the provider returns an error (ResolveError),
+// or if the provider panics (ProviderPanicError).
 func TryResolveNamed[T any](c *Container, name string) (T, error) {
 	return TryResolveNamedContext[T](c.ctx, c, name)
 }
@@ -55,7 +107,8 @@ func TryResolveNamed[T any](c *Container, name string) (T, error) {
 //
 // An error is returned if a provider cannot be found for the specified type
 // and name (MissingProviderError), if there is dependency cycle in resolving
-// (CircularDependencyError), or if the provider returns an error.
+// (CircularDependencyError), if the provider returns an error (ResolveError),
+// or if the provider panics (ProviderPanicError).
 func TryResolve[T any](c *Container) (T, error) {
 	return TryResolveContext[T](c.ctx, c)
 }
@@ -88,3 +141,23 @@ func ResolveContext[T any](ctx context.Context, c *Container) T {
 func Resolve[T any](c *Container) T {
 	return ResolveContext[T](c.ctx, c)
 }
+
+// resolveError wraps an error returned by the provider for name in a
+// ResolveError. If err already contains a ResolveError or ProviderPanicError
+// from a deeper provider, it is returned as-is to preserve the full resolving
+// chain.
+func (c *Container) resolveError(name typeName, err error) error {
+	if hasChain(err) {
+		return err
+	}
+
+	return ResolveError{
+		chain: c.resolvingChain(name),
+		Err:   err,
+	}
+}
+
+// hasChain reports whether err contains an error recording a resolving chain.
+func hasChain(err error) bool {
+	return errors.As(err, &ResolveError{}) || errors.As(err, &ProviderPanicError{})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTryResolveNamedContext(t *testing.T) {
@@ -40,7 +41,35 @@ func TestTryResolveNamedContext(t *testing.T) {
 
 		out, err := TryResolveNamedContext[int](context.Background(), c, "fizz")
 		assert.Zero(t, out)
-		assert.Equal(t, exErr, err)
+		assert.ErrorIs(t, err, exErr)
+		assert.ErrorAs(t, err, &ResolveError{})
+	})
+
+	t.Run("nested error", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		exErr := errors.New("some error")
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			return TryResolveNamed[int](c, "buzz")
+		})
+		BindNamed(c, "buzz", func(c *Container) (int, error) {
+			return TryResolve[int](c)
+		})
+		Bind(c, func(_ *Container) (int, error) {
+			return 0, exErr
+		})
+
+		_, err := TryResolveNamedContext[int](context.Background(), c, "fizz")
+		assert.ErrorIs(t, err, exErr)
+
+		resErr := ResolveError{}
+		require.ErrorAs(t, err, &resErr)
+		assert.Equal(t, []typeName{
+			newTypeName[int]("fizz"),
+			newTypeName[int]("buzz"),
+			newTypeName[int](anonymous),
+		}, resErr.chain)
 	})
 
 	t.Run("missing provider", func(t *testing.T) {
@@ -53,6 +82,73 @@ func TestTryResolveNamedContext(t *testing.T) {
 		assert.ErrorAs(t, err, &MissingProviderError{})
 	})
 
+	t.Run("missing dependency", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "foo", func(c *Container) (int, error) {
+			return TryResolveNamed[int](c, "bar")
+		})
+
+		_, err := TryResolveNamedContext[int](context.Background(), c, "foo")
+		mpErr := MissingProviderError{}
+		require.ErrorAs(t, err, &mpErr)
+		require.NotEmpty(t, mpErr.Hints)
+		assert.Contains(t, mpErr.Hints[0], "required by int:foo (bound at ")
+		assert.Contains(t, mpErr.Hints[0], "resolve_test.go:")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			return ResolveNamed[int](c, "buzz"), nil
+		})
+		BindNamed(c, "buzz", func(c *Container) (int, error) {
+			panic("oh no")
+		})
+
+		out, err := TryResolveNamedContext[int](context.Background(), c, "fizz")
+		assert.Zero(t, out)
+
+		ppErr := ProviderPanicError{}
+		require.ErrorAs(t, err, &ppErr)
+		assert.Equal(t, "oh no", ppErr.Value)
+		assert.Equal(t, []typeName{
+			newTypeName[int]("fizz"),
+			newTypeName[int]("buzz"),
+		}, ppErr.chain)
+		assert.Contains(t, string(ppErr.Stack), "resolve_test.go")
+	})
+
+	t.Run("panic resolving", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			return ResolveNamed[int](c, "buzz"), nil
+		})
+
+		_, err := TryResolveNamedContext[int](context.Background(), c, "fizz")
+		assert.ErrorAs(t, err, &ProviderPanicError{})
+		assert.ErrorAs(t, err, &MissingProviderError{})
+	})
+
+	t.Run("repanic", func(t *testing.T) {
+		t.Parallel()
+
+		c := new(Container)
+		c.Repanic()
+		BindNamed(c, "fizz", func(c *Container) (int, error) {
+			panic("oh no")
+		})
+
+		assert.PanicsWithValue(t, "oh no", func() {
+			_, _ = TryResolveNamedContext[int](context.Background(), c.Extend(), "fizz")
+		})
+	})
+
 	t.Run("circular reference", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,46 @@
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// suggestions returns hints for bindings visible from the Container that are
+// near matches for the missing name, such as the same type bound with a
+// different name or a pointer bound where a value was requested.
+func (c *Container) suggestions(name typeName) []string {
+	var out []string
+	for _, b := range c.Bindings() {
+		if b.Shadowed {
+			continue
+		}
+		if reason := nearMatch(name, b.typeName()); reason != "" {
+			out = append(out, fmt.Sprintf("did you mean %v (bound at %s)? %s", b.typeName(), b.Source, reason))
+		}
+	}
+	return out
+}
+
+// nearMatch returns the reason candidate may have been intended when
+// requesting name, or an empty string if it is not a near match.
+func nearMatch(name, candidate typeName) string {
+	want, got := name.Type, candidate.Type
+	sameName := name.Name == candidate.Name && name.Qualifier == candidate.Qualifier
+
+	switch {
+	case want == got:
+		return "the type is bound with a different name"
+	case !sameName:
+		return ""
+	case got == reflect.PointerTo(want):
+		return "a pointer is bound, but a value was requested"
+	case want == reflect.PointerTo(got):
+		return "a value is bound, but a pointer was requested"
+	case got.Kind() == reflect.Interface && want.Kind() != reflect.Interface && want.Implements(got):
+		return "an interface implemented by the type is bound"
+	case got.Name() != "" && got.Name() == want.Name() && got.PkgPath() != want.PkgPath():
+		return fmt.Sprintf("the type is from package %q instead of %q", got.PkgPath(), want.PkgPath())
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,91 @@
+package ioc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	// Buffer shares its name with bytes.Buffer, but not its package
+	type Buffer struct{}
+
+	tests := []struct {
+		name     string
+		bind     func(c *Container)
+		missing  typeName
+		exReason string
+	}{
+		{
+			name:     "different name",
+			bind:     func(c *Container) { BindNamed(c, "foo", Static(123)) },
+			missing:  newTypeName[int]("bar"),
+			exReason: "did you mean int:foo (bound at ",
+		},
+		{
+			name:     "pointer bound",
+			bind:     func(c *Container) { Bind(c, Static(&someStruct{})) },
+			missing:  newTypeName[someStruct](anonymous),
+			exReason: "a pointer is bound, but a value was requested",
+		},
+		{
+			name:     "value bound",
+			bind:     func(c *Container) { Bind(c, Static(someStruct{})) },
+			missing:  newTypeName[*someStruct](anonymous),
+			exReason: "a value is bound, but a pointer was requested",
+		},
+		{
+			name:     "interface bound",
+			bind:     func(c *Container) { Bind(c, Static[io.Writer](&bytes.Buffer{})) },
+			missing:  newTypeName[*bytes.Buffer](anonymous),
+			exReason: "an interface implemented by the type is bound",
+		},
+		{
+			name:     "different package",
+			bind:     func(c *Container) { Bind(c, Static(bytes.Buffer{})) },
+			missing:  newTypeName[Buffer](anonymous),
+			exReason: `the type is from package "bytes" instead of "github.com/rodaine/ioc"`,
+		},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := new(Container)
+			tc.bind(c)
+			c.Freeze()
+
+			_, err := c.Extend().lookup(tc.missing)
+			mpErr := MissingProviderError{}
+			require.ErrorAs(t, err, &mpErr)
+			assert.Empty(t, mpErr.Hints, "should defer computing hints")
+
+			mpErr = c.Extend().missingProvider(tc.missing)
+			require.Len(t, mpErr.Hints, 1)
+			assert.Contains(t, mpErr.Hints[0], "suggest_test.go:")
+			assert.Contains(t, mpErr.Hints[0], tc.exReason)
+		})
+	}
+}
+
+func TestContainer_Suggestions_None(t *testing.T) {
+	t.Parallel()
+
+	c := new(Container)
+	BindNamed(c, "foo", Static("bar"))
+	Bind(c, Static(&someStruct{}))
+	BindNamed(c, "x", Static(123))
+	assert.Empty(t, c.suggestions(newTypeName[someStruct]("foo")))
+
+	child := c.Extend()
+	BindNamed(child, "x", Static(456))
+	assert.Len(t, child.suggestions(newTypeName[int]("y")), 1,
+		"should not suggest shadowed bindings")
+}
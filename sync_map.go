@@ -19,3 +19,22 @@ func (sm *syncMap[K, V]) Load(key K) (value V, ok bool) {
 func (sm *syncMap[K, V]) Store(key K, value V) {
 	sm.inner.Store(key, value)
 }
+
+func (sm *syncMap[K, V]) Range(fn func(key K, value V) bool) {
+	sm.inner.Range(func(k, v any) bool {
+		return fn(k.(K), v.(V))
+	})
+}
+
+func (sm *syncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	v, loaded := sm.inner.LoadOrStore(key, value)
+	return v.(V), loaded
+}
+
+func (sm *syncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	v, loaded := sm.inner.LoadAndDelete(key)
+	if loaded {
+		value = v.(V)
+	}
+	return value, loaded
+}
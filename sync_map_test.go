@@ -1 +1,29 @@
 package ioc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncMap_Range(t *testing.T) {
+	t.Parallel()
+
+	sm := syncMap[string, int]{}
+	sm.Store("foo", 1)
+	sm.Store("bar", 2)
+
+	out := map[string]int{}
+	sm.Range(func(k string, v int) bool {
+		out[k] = v
+		return true
+	})
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, out)
+
+	count := 0
+	sm.Range(func(string, int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
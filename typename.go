@@ -6,22 +6,44 @@ import (
 )
 
 type typeName struct {
-	Name string
-	Type reflect.Type
+	Name      string
+	Qualifier reflect.Type
+	Type      reflect.Type
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
 }
 
 func newTypeName[T any](name string) typeName {
 	return typeName{
 		Name: name,
-		Type: reflect.TypeOf((*T)(nil)).Elem(),
+		Type: typeOf[T](),
 	}
 }
 
+func newQualifiedTypeName[T, Q any]() typeName {
+	return typeName{
+		Qualifier: typeOf[Q](),
+		Type:      typeOf[T](),
+	}
+}
+
+// retype returns a copy of tn with the same name and qualifier, but for type T.
+func retype[T any](tn typeName) typeName {
+	tn.Type = typeOf[T]()
+	return tn
+}
+
 func (tn typeName) String() string {
-	if tn.Name == anonymous {
-		return tn.Type.String()
+	s := tn.Type.String()
+	if tn.Name != anonymous {
+		s = fmt.Sprintf("%s:%s", s, tn.Name)
+	}
+	if tn.Qualifier != nil {
+		s = fmt.Sprintf("%s@%v", s, tn.Qualifier)
 	}
-	return fmt.Sprintf("%v:%s", tn.Type, tn.Name)
+	return s
 }
 
 var _ fmt.Stringer = typeName{}
@@ -30,6 +30,10 @@ func TestTypeName_String(t *testing.T) {
 			tn: newTypeName[renamedPkg.Assertions](anonymous),
 			ex: "require.Assertions",
 		},
+		{
+			tn: newQualifiedTypeName[int, renamedPkg.Assertions](),
+			ex: "int@require.Assertions",
+		},
 	}
 
 	for _, tc := range tests {